@@ -23,51 +23,16 @@
 package archey
 
 import (
-	"bytes"
 	"fmt"
 	"os"
-	"os/user"
 	"path/filepath"
-	"strconv"
 	"strings"
-	"text/template"
+	"time"
+	"unicode/utf8"
 
-	"github.com/alexdreptu/sysinfo"
-	utils "github.com/alexdreptu/utils-go"
 	"github.com/mgutz/ansi"
 )
 
-type info map[string]string
-
-type Show struct {
-	OS              bool
-	Arch            bool
-	Kernel          bool
-	User            bool
-	Hostname        bool
-	Uptime          bool
-	UpSince         bool
-	WM              bool
-	DE              bool
-	GTK2Theme       bool
-	GTK2IconTheme   bool
-	GTK2Font        bool
-	GTK2CursorTheme bool
-	GTK3Theme       bool
-	GTK3IconTheme   bool
-	GTK3Font        bool
-	GTK3CursorTheme bool
-	Terminal        bool
-	Shell           bool
-	Editor          bool
-	Packages        bool
-	Memory          bool
-	Swap            bool
-	CPU             bool
-	Root            bool
-	Home            bool
-}
-
 type Colors struct {
 	Name string
 	Text string
@@ -76,16 +41,52 @@ type Colors struct {
 }
 
 type Options struct {
-	Sep           string
-	DiskUnit      string
-	MemoryUnit    string
-	SwapUnit      string
-	Paths         []string
-	PathFull      bool
-	ShellFull     bool
-	UpSinceFormat string
-	Show          Show
-	Colors        Colors
+	Sep        string
+	DiskUnit   string
+	MemoryUnit string
+	SwapUnit   string
+	Paths      []string
+	// NoPackageManagers holds the names of the
+	// package managers whose packages aren't counted
+	NoPackageManagers []string
+	PathFull          bool
+	ShellFull         bool
+	UpSinceFormat     string
+	NoArch            bool
+	// Interfaces holds glob patterns of the network interfaces to show,
+	// patterns starting with ! hide the interfaces they match
+	Interfaces []string
+	// MaskAddresses hides the host part of network addresses
+	MaskAddresses bool
+	// Custom holds the user-defined lines shown after the other fields
+	Custom []Custom
+	// Sysroot is the root the system files are read relative to,
+	// e.g. a host mounted at /host inside a container
+	Sysroot string
+	// Logo is the name of the logo to use,
+	// if empty the logo is selected from os-release
+	Logo string
+	// LogoFile is an ASCII art file used instead of Logo
+	LogoFile string
+	// Image is a PNG, JPEG or GIF file displayed instead of the logo
+	Image string
+	// ImageProtocol is how Image is displayed: kitty, sixel, iterm,
+	// blocks or auto to pick one based on the terminal
+	ImageProtocol string
+	// ImageWidth is the width of Image in terminal columns
+	ImageWidth int
+	// Hide holds the names of the providers that won't be displayed
+	Hide map[string]bool
+	// Modules holds the names of the providers to display in order,
+	// along with the separator and blank pseudo-modules.
+	// When it's set Hide is ignored.
+	Modules []string
+	// Timeout is how long collecting a field may take
+	// before it's displayed as timed out, zero means no limit
+	Timeout time.Duration
+	// Timeouts overrides Timeout for the fields named by its keys
+	Timeouts map[string]time.Duration
+	Colors   Colors
 }
 
 // pacman's local database of installed packages
@@ -98,6 +99,7 @@ const (
 	defMemoryUnit    = defDiskUnit             // default unit for disk usage
 	defSwapUnit      = defMemoryUnit           // default unit for memory usage
 	defUpSinceFormat = "%a, %d %b %Y at %T %Z" // strftime format
+	defTimeout       = 2 * time.Second         // default timeout of each field
 )
 
 // Name Sep Info
@@ -113,27 +115,6 @@ const (
 	resetColor        = "reset"   // reset color
 )
 
-const archLogo = `
-                  {{.bCol1}}##{{.reset}}                      {{.info0}}
-                 {{.bCol1}}####{{.reset}}                     {{.info1}}
-                {{.bCol1}}######{{.reset}}                    {{.info2}}
-               {{.bCol1}}########{{.reset}}                   {{.info3}}
-              {{.bCol1}}##########{{.reset}}                  {{.info4}}
-             {{.bCol1}}############{{.reset}}                 {{.info5}}
-            {{.bCol1}}##############{{.reset}}                {{.info6}}
-           {{.bCol1}}################{{.reset}}               {{.info7}}
-          {{.bCol1}}##################{{.reset}}              {{.info8}}
-         {{.bCol1}}#########{{.bCol2}}########{{.bCol1}}###{{.reset}}             {{.info9}}
-        {{.bCol1}}###{{.bCol2}}#################{{.bCol1}}##{{.reset}}            {{.info10}}
-       {{.bCol1}}##{{.bCol2}}#######{{.reset}}      {{.bCol2}}#########{{.reset}}           {{.info11}}
-      {{.bCol2}}########;{{.reset}}        {{.bCol2}};########{{.reset}}          {{.info12}}
-     {{.bCol2}}########;{{.reset}}          {{.bCol2}};########{{.reset}}         {{.info13}}
-    {{.bCol2}}##########.{{.reset}}        {{.bCol2}}.##########{{.reset}}        {{.info14}}
-   {{.bCol2}}#######{{.reset}}                  {{.bCol2}}#######{{.reset}}       {{.info15}}
-  {{.bCol2}}#####{{.reset}}                        {{.bCol2}}#####{{.reset}}      {{.info16}}
- {{.bCol2}}###{{.reset}}                              {{.bCol2}}###{{.reset}}     {{.info17}}
-{{.bCol2}}##{{.reset}}                                  {{.bCol2}}##{{.reset}}    {{.info18}}`
-
 var (
 	ErrInvalidMemUnit = func(u string) error {
 		return fmt.Errorf("invalid memory unit '%s'", u)
@@ -144,13 +125,22 @@ var (
 	ErrInvalidDiskUnit = func(u string) error {
 		return fmt.Errorf("invalid disk unit '%s'", u)
 	}
+	ErrInvalidModule = func(m string) error {
+		return fmt.Errorf("invalid module '%s'", m)
+	}
+)
+
+// pseudo-modules which can be placed between the fields
+const (
+	moduleSeparator = "separator" // a line as wide as the widest field
+	moduleBlank     = "blank"     // an empty line
 )
 
 // gtk config locations
-var (
-	// user wide
-	userGTK2rc = filepath.Join(os.Getenv("HOME"), ".gtkrc-2.0")
-	userGTK3rc = filepath.Join(os.Getenv("HOME"), ".config/gtk-3.0/settings.ini")
+const (
+	// user wide, relative to the home directory
+	userGTK2rc = ".gtkrc-2.0"
+	userGTK3rc = ".config/gtk-3.0/settings.ini"
 	// system wide
 	sysGTK2rc = "/etc/gtk-2.0/gtkrc"
 	sysGTK3rc = "/etc/gtk-3.0/settings.ini"
@@ -159,454 +149,253 @@ var (
 // Render returns the rendered logo with all
 // the information added based on the specified options
 func (o *Options) Render() (string, error) {
-	info, err := getFormattedInfo(o)
+	si, err := Collect(o)
 	if err != nil {
 		return "", err
 	}
 
-	var bCol1 string
-	var bCol2 string
-	bColors := func() []string {
-		var sl []string
-		if len(o.Colors.Body) > 1 {
-			for _, color := range o.Colors.Body {
-				sl = append(sl, color)
-			}
-		} else {
-			sl = strings.Split(o.Colors.Body[0], ",")
-		}
-		return sl
-	}()
-
-	if len(bColors) > 1 {
-		bCol1 = ansi.ColorCode(bColors[0])
-		bCol2 = ansi.ColorCode(bColors[1])
-	} else {
-		bCol1 = ansi.ColorCode(bColors[0])
-		bCol2 = bCol1
-	}
+	return o.RenderInfo(si)
+}
 
-	data := map[string]string{
-		"bCol1": bCol1,
-		"bCol2": bCol2,
-		"reset": ansi.ColorCode(resetColor),
+// RenderInfo returns the rendered logo with the information
+// previously collected in si, formatted based on the options
+func (o *Options) RenderInfo(si SystemInfo) (string, error) {
+	info, err := getFormattedInfo(o, si)
+	if err != nil {
+		return "", err
 	}
 
-	logoSize := len(strings.Split(archLogo, "\n")) - 1
-
-	// create the info spots for logo
-	// and set each to empty string
-	for i := 0; i < logoSize; i++ {
-		key := "info" + strconv.Itoa(i)
-		data[key] = ""
+	// half blocks without colors are a solid slab,
+	// so the logo is displayed instead
+	if o.Image != "" && (!plain || o.imageProtocol() != imageBlocks) {
+		return o.renderImage(info)
 	}
 
-	// fill each info spot with formatted info
-	for i, v := range info {
-		key := "info" + strconv.Itoa(i)
-		data[key] = v
+	l, err := o.logo()
+	if err != nil {
+		return "", err
 	}
 
-	var dataCount int
-	for k, _ := range data {
-		if strings.HasPrefix(k, "info") {
-			dataCount++
-		}
+	// the body color may have been split on its commas as a flag
+	bodyColor := strings.Join(o.Colors.Body, ",")
+	if len(o.Colors.Body) == 0 {
+		bodyColor = defBodyColorUpper + "," + defBodyColorLower
 	}
 
-	// create a slice and add the content of archLogo
-	// to be able to extend it as necessary
-	logo := []string{archLogo}
-
-	if dataCount > logoSize {
-		for i := logoSize; i < dataCount; i++ {
-			infon := fmt.Sprintf("{{.info%s}}", strconv.Itoa(i))
-			logo = append(logo, "\t\t\t\t\t  "+infon)
-		}
+	b, err := parseBody(bodyColor)
+	if err != nil {
+		return "", err
 	}
 
-	// always append one empty line at the end of the info
-	logo = append(logo, "")
-
-	t, err := template.New("logo").Parse(strings.Join(logo, "\n"))
+	art, err := b.paint(l.Lines(), termColorDepth())
 	if err != nil {
 		return "", err
 	}
 
-	var buf bytes.Buffer
-	if err = t.Execute(&buf, data); err != nil {
-		return "", err
+	widths := make([]int, len(art))
+	for i, line := range l.Lines() {
+		widths[i] = visibleWidth(line)
 	}
 
-	return buf.String(), nil
+	return joinColumns(art, widths, l.Width(), info), nil
 }
 
-func getFormattedInfo(opt *Options) ([]string, error) {
-	nameColor := ansi.ColorFunc(opt.Colors.Name)
-	textColor := ansi.ColorFunc(opt.Colors.Text)
-	sepColor := ansi.ColorFunc(opt.Colors.Sep)
-	diskUnit := strings.ToLower(opt.DiskUnit)
-
-	// hold the info format lines
-	info := []string{}
+// joinColumns places the info lines to the right of the art lines,
+// whose visible widths are in widths and the widest of which is width
+func joinColumns(art []string, widths []int, width int, info []string) string {
+	reset := ansi.ColorCode(resetColor)
+	// the info lines start after the widest line of the logo
+	width += logoGap
 
-	node := sysinfo.Node{}
-	if err := node.Get(); err != nil {
-		return nil, err
+	lines := len(art)
+	if len(info) > lines {
+		lines = len(info)
 	}
 
-	if !opt.Show.OS {
-		osName := node.OSName + " " + node.Machine
-		if opt.Show.Arch {
-			osName = node.OSName
-		}
+	// start with an empty line
+	logo := []string{""}
 
-		distroFormat := fmt.Sprintf(infoFormat,
-			nameColor("OS"), sepColor(opt.Sep), textColor(osName))
-		info = append(info, distroFormat)
-	}
-
-	if !opt.Show.Kernel {
-		kernelFormat := fmt.Sprintf(infoFormat,
-			nameColor("Kernel"), sepColor(opt.Sep), textColor(node.Release))
-		info = append(info, kernelFormat)
-	}
-
-	if !opt.Show.User {
-		usr, err := user.Current()
-		if err != nil {
-			return nil, err
+	for i := 0; i < lines; i++ {
+		var line string
+		var lineWidth int
+		if i < len(art) {
+			line = art[i] + reset
+			lineWidth = widths[i]
 		}
-		userFormat := fmt.Sprintf(infoFormat,
-			nameColor("User"), sepColor(opt.Sep), textColor(usr.Username))
-		info = append(info, userFormat)
-	}
 
-	if !opt.Show.Hostname {
-		hostnameFormat := fmt.Sprintf(infoFormat,
-			nameColor("Hostname"), sepColor(opt.Sep), textColor(node.NodeName))
-		info = append(info, hostnameFormat)
+		if i < len(info) {
+			line += strings.Repeat(" ", width-lineWidth) + info[i]
+		}
+		logo = append(logo, line)
 	}
 
-	up := sysinfo.Uptime{}
-	if err := up.Get(); err != nil {
-		return nil, err
-	}
+	// always append one empty line at the end of the info
+	logo = append(logo, "")
 
-	if !opt.Show.Uptime {
-		uptimeFormat := fmt.Sprintf(infoFormat,
-			nameColor("Uptime"), sepColor(opt.Sep), textColor(up.String()))
-		info = append(info, uptimeFormat)
-	}
+	return strings.Join(logo, "\n")
+}
 
-	if !opt.Show.UpSince {
-		upSinceFormat := fmt.Sprintf(infoFormat,
-			nameColor("Up since"),
-			sepColor(opt.Sep),
-			textColor(up.UpSinceFormat(opt.UpSinceFormat)))
-		info = append(info, upSinceFormat)
+// getFormattedInfo formats and colors the values collected in si
+func getFormattedInfo(opt *Options, si SystemInfo) ([]string, error) {
+	depth := termColorDepth()
+	nameColor, err := colorFunc(opt.Colors.Name, depth)
+	if err != nil {
+		return nil, err
 	}
-
-	if !opt.Show.WM {
-		wmFormat := fmt.Sprintf(infoFormat,
-			nameColor("Window Manager"), sepColor(opt.Sep), textColor(GetWM()))
-		info = append(info, wmFormat)
+	textColor, err := colorFunc(opt.Colors.Text, depth)
+	if err != nil {
+		return nil, err
 	}
-
-	if !opt.Show.DE {
-		deFormat := fmt.Sprintf(infoFormat,
-			nameColor("Desktop Environment"), sepColor(opt.Sep), textColor(GetDE()))
-		info = append(info, deFormat)
+	sepColor, err := colorFunc(opt.Colors.Sep, depth)
+	if err != nil {
+		return nil, err
 	}
 
-	var gtk GTK
-	var err error
-
-	// don't repeat yourself
-	var setGtkNone = func() {
-		gtk.Theme = "None"
-		gtk.Icons = "None"
-		gtk.Font = "None"
-		gtk.Cursor = "None"
+	modules, err := opt.modules()
+	if err != nil {
+		return nil, err
 	}
 
-	// if ~/.gtkrc-2.0 exists use it
-	// otherwise use the system wide /etc/gtk-2.0/gtkrc
-	if utils.IsExistFile(userGTK2rc) {
-		gtk, err = GetGTKInfo(userGTK2rc)
-		if err != nil {
-			setGtkNone()
-		}
-	} else if utils.IsExistFile(sysGTK2rc) {
-		gtk, err = GetGTKInfo(sysGTK2rc)
-		if err != nil {
-			setGtkNone()
+	// hold the info format lines
+	info := []string{}
+	// the separators are drawn once the widest line is known
+	var separators []int
+	width := 0
+
+	addLine := func(name, text string) {
+		info = append(info, fmt.Sprintf(infoFormat,
+			nameColor(name), sepColor(opt.Sep), textColor(text)))
+		if w := utf8.RuneCountInString(name + opt.Sep + " " + text); w > width {
+			width = w
 		}
-	} else {
-		setGtkNone()
-	}
-
-	if !opt.Show.GTK2Theme {
-		gtkThemeFormat := fmt.Sprintf(infoFormat,
-			nameColor("GTK2 Theme"), sepColor(opt.Sep), textColor(gtk.Theme))
-		info = append(info, gtkThemeFormat)
 	}
 
-	if !opt.Show.GTK2IconTheme {
-		gtkIconsFormat := fmt.Sprintf(infoFormat,
-			nameColor("GTK2 Icon Theme"), sepColor(opt.Sep), textColor(gtk.Icons))
-		info = append(info, gtkIconsFormat)
-	}
-
-	if !opt.Show.GTK2Font {
-		gtkFontFormat := fmt.Sprintf(infoFormat,
-			nameColor("GTK2 Font"), sepColor(opt.Sep), textColor(gtk.Font))
-		info = append(info, gtkFontFormat)
-	}
+	for _, name := range modules {
+		switch name {
+		case moduleSeparator:
+			separators = append(separators, len(info))
+			info = append(info, "")
+			continue
+		case moduleBlank:
+			info = append(info, "")
+			continue
+		}
 
-	if !opt.Show.GTK2CursorTheme {
-		gtkCursorFormat := fmt.Sprintf(infoFormat,
-			nameColor("GTK2 Cursor Theme"), sepColor(opt.Sep), textColor(gtk.Cursor))
-		info = append(info, gtkCursorFormat)
-	}
+		p, _ := Lookup(name)
+		if si.timedOut(p.Name()) {
+			addLine(p.Label(), timeoutText)
+			continue
+		}
 
-	// if ~/.config/gtkrc-3.0/settings.ini exists use it
-	// otherwise use the system wide /etc/gtk-3.0/settings.ini
-	if utils.IsExistFile(userGTK3rc) {
-		gtk, err = GetGTKInfo(userGTK3rc)
-		if err != nil {
-			setGtkNone()
+		v, ok := si.Value(p.Name())
+		if !ok {
+			continue
 		}
-	} else if utils.IsExistFile(sysGTK3rc) {
-		gtk, err = GetGTKInfo(sysGTK3rc)
+
+		lines, err := p.Format(opt, v)
 		if err != nil {
-			setGtkNone()
+			return nil, err
 		}
-	} else {
-		setGtkNone()
-	}
-
-	if !opt.Show.GTK3Theme {
-		gtkThemeFormat := fmt.Sprintf(infoFormat,
-			nameColor("GTK3 Theme"), sepColor(opt.Sep), textColor(gtk.Theme))
-		info = append(info, gtkThemeFormat)
-	}
-
-	if !opt.Show.GTK3IconTheme {
-		gtkIconsFormat := fmt.Sprintf(infoFormat,
-			nameColor("GTK3 Icon Theme"), sepColor(opt.Sep), textColor(gtk.Icons))
-		info = append(info, gtkIconsFormat)
-	}
-
-	if !opt.Show.GTK3Font {
-		gtkFontFormat := fmt.Sprintf(infoFormat,
-			nameColor("GTK3 Font"), sepColor(opt.Sep), textColor(gtk.Font))
-		info = append(info, gtkFontFormat)
-	}
 
-	if !opt.Show.GTK3CursorTheme {
-		gtkCursorFormat := fmt.Sprintf(infoFormat,
-			nameColor("GTK3 Cursor Theme"), sepColor(opt.Sep), textColor(gtk.Cursor))
-		info = append(info, gtkCursorFormat)
+		for _, line := range lines {
+			addLine(line.Name, line.Text)
+		}
 	}
 
-	if !opt.Show.Terminal {
-		terminalFormat := fmt.Sprintf(infoFormat,
-			nameColor("Terminal"), sepColor(opt.Sep), textColor(os.Getenv("TERM")))
-		info = append(info, terminalFormat)
+	for _, i := range separators {
+		info[i] = sepColor(strings.Repeat("-", width))
 	}
 
-	if !opt.Show.Shell {
-		var shell string
+	return info, nil
+}
 
-		if opt.ShellFull {
-			shell = os.Getenv("SHELL")
-		} else {
-			shell = strings.Title(filepath.Base(os.Getenv("SHELL")))
+// modules returns the names of the modules to display in order, which
+// are either the ones set in Modules or all the providers not hidden
+func (o *Options) modules() ([]string, error) {
+	modules := splitList(o.Modules)
+	if len(modules) == 0 {
+		for _, p := range Providers() {
+			if !o.Hide[p.Name()] {
+				modules = append(modules, p.Name())
+			}
 		}
-
-		shellFormat := fmt.Sprintf(infoFormat,
-			nameColor("Shell"), sepColor(opt.Sep), textColor(shell))
-		info = append(info, shellFormat)
+		return modules, nil
 	}
 
-	if !opt.Show.Editor {
-		editor := strings.Title(os.Getenv("EDITOR"))
-		editorFormat := fmt.Sprintf(infoFormat,
-			nameColor("Editor"), sepColor(opt.Sep), textColor(editor))
-		info = append(info, editorFormat)
-	}
-
-	if !opt.Show.Packages {
-		count, err := utils.CountDir(pacmanDir)
-		// if pacmanDir doesn't exist set count.Dirs to 0
-		// instead of returning error and exiting
-		if err != nil {
-			count.Dirs = 0
+	for _, m := range modules {
+		if _, ok := Lookup(m); !ok && m != moduleSeparator && m != moduleBlank {
+			return nil, ErrInvalidModule(m)
 		}
-
-		n := strconv.Itoa(count.Dirs)
-		packagesFormat := fmt.Sprintf(infoFormat,
-			nameColor("Packages"), sepColor(opt.Sep), textColor(n))
-		info = append(info, packagesFormat)
-	}
-
-	mem := sysinfo.Mem{}
-	if err := mem.Get(); err != nil {
-		return nil, err
 	}
+	return modules, nil
+}
 
-	if !opt.Show.Memory {
-		var memUsage string
-		switch strings.ToLower(opt.MemoryUnit) {
-		case "mb":
-			memUsage = fmt.Sprintf("%.1f MB / %.1f MB",
-				mem.UsedMemInMB(), mem.TotalMemInMB())
-		case "gb":
-			memUsage = fmt.Sprintf("%.1f GB / %.1f GB",
-				mem.UsedMemInGB(), mem.TotalMemInGB())
-		default:
-			return nil, ErrInvalidMemUnit(opt.MemoryUnit)
+// shown reports whether the provider name is displayed
+func (o *Options) shown(name string) bool {
+	if modules := splitList(o.Modules); len(modules) > 0 {
+		for _, m := range modules {
+			if m == name {
+				return true
+			}
 		}
-
-		memoryFormat := fmt.Sprintf(infoFormat,
-			nameColor("Memory"), sepColor(opt.Sep), textColor(memUsage))
-		info = append(info, memoryFormat)
+		return false
 	}
+	return !o.Hide[name]
+}
 
-	if !opt.Show.Swap {
-		var swapUsage string
-		switch strings.ToLower(opt.SwapUnit) {
-		case "mb":
-			swapUsage = fmt.Sprintf("%.1f MB / %.1f MB",
-				mem.UsedSwapInMB(), mem.TotalSwapInMB())
-		case "gb":
-			swapUsage = fmt.Sprintf("%.1f GB / %.1f GB",
-				mem.UsedSwapInGB(), mem.TotalSwapInGB())
-		default:
-			return nil, ErrInvalidSwapUnit(opt.SwapUnit)
-		}
-
-		swapFormat := fmt.Sprintf(infoFormat,
-			nameColor("Swap"), sepColor(opt.Sep), textColor(swapUsage))
-		info = append(info, swapFormat)
+// path returns the path of the system file p relative to Sysroot
+func (o *Options) path(p string) string {
+	if o.Sysroot == "" {
+		return p
 	}
+	return filepath.Join(o.Sysroot, p)
+}
 
-	if !opt.Show.CPU {
-		cpu := sysinfo.CPU{}
-		if err := cpu.Get(); err != nil {
-			return nil, err
-		}
-
-		cpuFormat := fmt.Sprintf(infoFormat,
-			nameColor("CPU"), sepColor(opt.Sep), textColor(cpu.Name))
-		info = append(info, cpuFormat)
+// home returns the file f of the home directory relative to Sysroot,
+// or an empty string when HOME isn't set
+func (o *Options) home(f string) string {
+	h := os.Getenv("HOME")
+	if h == "" {
+		return ""
 	}
+	return o.path(filepath.Join(h, f))
+}
 
-	if !opt.Show.Root {
-		rootfs := sysinfo.FS{}
-		if err := rootfs.Get("/"); err != nil {
-			return nil, err
-		}
-
-		var rootfsUsage string
-		switch diskUnit {
-		case "mb":
-			rootfsUsage = fmt.Sprintf("%.1f MB / %.1f MB",
-				rootfs.UsedSpaceInMB(), rootfs.TotalSizeInMB())
-		case "gb":
-			rootfsUsage = fmt.Sprintf("%.1f GB / %.1f GB",
-				rootfs.UsedSpaceInGB(), rootfs.TotalSizeInGB())
-		default:
-			return nil, ErrInvalidDiskUnit(opt.DiskUnit)
-		}
-
-		pathName := "Root"
-		if opt.PathFull {
-			pathName = "/root"
-		}
-
-		rootFormat := fmt.Sprintf(infoFormat,
-			nameColor(pathName), sepColor(opt.Sep), textColor(rootfsUsage))
-		info = append(info, rootFormat)
+// paths returns the system files in sl relative to Sysroot
+func (o *Options) paths(sl []string) []string {
+	var paths []string
+	for _, p := range sl {
+		paths = append(paths, o.path(p))
 	}
+	return paths
+}
 
-	if !opt.Show.Home {
-		homefs := sysinfo.FS{}
-		if err := homefs.Get("/home"); err != nil {
-			return nil, err
-		}
-
-		var homefsUsage string
-		switch diskUnit {
-		case "mb":
-			homefsUsage = fmt.Sprintf("%.1f MB / %.1f MB",
-				homefs.UsedSpaceInMB(), homefs.TotalSizeInMB())
-		case "gb":
-			homefsUsage = fmt.Sprintf("%.1f GB / %.1f GB",
-				homefs.UsedSpaceInGB(), homefs.TotalSizeInGB())
-		default:
-			return nil, ErrInvalidDiskUnit(opt.DiskUnit)
-		}
-
-		pathName := "Home"
-		if opt.PathFull {
-			pathName = "/home"
-		}
-
-		homeFormat := fmt.Sprintf(infoFormat,
-			nameColor(pathName), sepColor(opt.Sep), textColor(homefsUsage))
-		info = append(info, homeFormat)
+// timeout returns the timeout of the named provider
+func (o *Options) timeout(name string) time.Duration {
+	if d, ok := o.Timeouts[name]; ok {
+		return d
 	}
+	return o.Timeout
+}
 
-	if len(opt.Paths) != 0 {
-		// NOTE: fix to viper's slice bind handling problem
-		paths := func() []string {
-			var sl []string
-			// if theres more than one path string in the slice
-			if len(opt.Paths) > 1 {
-				// iterate over each path
-				for _, path := range opt.Paths {
-					// append it
-					sl = append(sl, path)
-				}
-			} else {
-				// otherwhise split the first and only path string
-				sl = strings.Split(opt.Paths[0], ",")
-			}
-			return sl
-		}()
-
-		for _, path := range paths {
-			pathfs := sysinfo.FS{}
-			if err := pathfs.Get(path); err != nil {
-				return nil, err
-			}
-
-			var pathfsUsage string
-			switch diskUnit {
-			case "mb":
-				pathfsUsage = fmt.Sprintf("%.1f MB / %.1f MB",
-					pathfs.UsedSpaceInMB(), pathfs.TotalSizeInMB())
-			case "gb":
-				pathfsUsage = fmt.Sprintf("%.1f GB / %.1f GB",
-					pathfs.UsedSpaceInGB(), pathfs.TotalSizeInGB())
-			default:
-				return nil, ErrInvalidDiskUnit(opt.DiskUnit)
-			}
+// splitList returns the items of a list option
+func splitList(list []string) []string {
+	// NOTE: fix to viper's slice bind handling problem
+	// if theres more than one string in the slice use it as is
+	// otherwhise split the first and only string
+	if len(list) > 1 {
+		return list
+	}
 
-			var pathFormat string
-			if !opt.PathFull {
-				path = strings.Title(strings.ToLower(filepath.Base(path)))
+	var sl []string
+	if len(list) == 1 {
+		for _, item := range strings.Split(list[0], ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				sl = append(sl, item)
 			}
-
-			pathFormat = fmt.Sprintf(infoFormat,
-				nameColor(path), sepColor(opt.Sep), textColor(pathfsUsage))
-			info = append(info, pathFormat)
 		}
 	}
-
-	return info, nil
+	return sl
 }
 
 func New() *Options {
@@ -618,33 +407,9 @@ func New() *Options {
 		PathFull:      false,
 		ShellFull:     false,
 		UpSinceFormat: defUpSinceFormat,
-		Show: Show{
-			OS:              true,
-			Arch:            true,
-			Kernel:          true,
-			User:            true,
-			Hostname:        true,
-			Uptime:          true,
-			UpSince:         true,
-			WM:              true,
-			DE:              true,
-			GTK2Theme:       true,
-			GTK2IconTheme:   true,
-			GTK2Font:        true,
-			GTK2CursorTheme: true,
-			GTK3Theme:       true,
-			GTK3IconTheme:   true,
-			GTK3Font:        true,
-			GTK3CursorTheme: true,
-			Terminal:        true,
-			Shell:           true,
-			Editor:          true,
-			Packages:        true,
-			Memory:          true,
-			CPU:             true,
-			Root:            true,
-			Home:            true,
-		},
+		Hide:          make(map[string]bool),
+		Timeout:       defTimeout,
+		Timeouts:      make(map[string]time.Duration),
 		Colors: Colors{
 			Name: defNameColor,
 			Sep:  defSepColor,
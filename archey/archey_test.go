@@ -0,0 +1,216 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+const sysroot = "testdata/sysroot"
+
+// setenv sets the environment variables in env for the duration of the test
+func setenv(t *testing.T, env map[string]string) {
+	for k, v := range env {
+		old, ok := os.LookupEnv(k)
+		os.Setenv(k, v)
+		k := k
+		t.Cleanup(func() {
+			if ok {
+				os.Setenv(k, old)
+			} else {
+				os.Unsetenv(k)
+			}
+		})
+	}
+}
+
+// golden compares got with the golden file f, or updates f with -update
+func golden(t *testing.T, f, got string) {
+	if *update {
+		if err := ioutil.WriteFile(f, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("output doesn't match %s, got:\n%s\nwant:\n%s", f, got, want)
+	}
+}
+
+// infoOf returns the SystemInfo holding values as collected by the providers
+// they're keyed by, the providers left out are hidden
+func infoOf(t *testing.T, values map[string]interface{}) SystemInfo {
+	var si SystemInfo
+	for name, v := range values {
+		p, ok := Lookup(name)
+		if !ok {
+			t.Fatalf("no provider '%s'", name)
+		}
+		si.set(p, v)
+	}
+	return si
+}
+
+func TestRenderSysroot(t *testing.T) {
+	setenv(t, map[string]string{
+		"TERM":         "xterm-256color",
+		"COLORTERM":    "",
+		"TERM_PROGRAM": "WezTerm",
+		"HOME":         "/home/user",
+	})
+
+	o := New()
+	o.Sysroot = sysroot
+	// the architecture is the one of the running kernel
+	o.NoArch = true
+	o.Modules = []string{"os", "kernel", "hostname", "uptime", "terminal",
+		"separator", "packages", "memory", "swap", "cpu", "gpu", "displays",
+		"blank", "battery", "network"}
+
+	info, err := o.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "testdata/render.golden", info)
+}
+
+func TestSysrootProcesses(t *testing.T) {
+	setenv(t, map[string]string{
+		"TERM_PROGRAM": "",
+		"TERM":         "xterm-kitty",
+		"SHELL":        "/usr/bin/fish",
+		"FISH_VERSION": "3.7.1",
+	})
+
+	// archey-go's pid doesn't belong to the proc file system of the sysroot,
+	// so the terminal and shell come from the environment, but not the
+	// version of the shell
+	o := New()
+	o.Sysroot = sysroot
+
+	term, err := collectTerminal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if term != "xterm-kitty" {
+		t.Errorf("got terminal %q, want %q", term, "xterm-kitty")
+	}
+
+	sh, err := collectShell(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := sh.(*Shell); s.Name != "fish" || s.Path != "/usr/bin/fish" || s.Version != "" {
+		t.Errorf("got shell %+v, want fish at /usr/bin/fish without a version", s)
+	}
+}
+
+func TestModules(t *testing.T) {
+	var all []string
+	for _, p := range Providers() {
+		if p.Name() != "kernel" {
+			all = append(all, p.Name())
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modules []string
+		want    []string
+		err     bool
+	}{
+		{"providers in registration order", nil, all, false},
+		{"modules of a flag", []string{"kernel, separator ,os,,blank"},
+			[]string{"kernel", "separator", "os", "blank"}, false},
+		{"modules of the config", []string{"cpu", "kernel", "cpu"},
+			[]string{"cpu", "kernel", "cpu"}, false},
+		{"unknown module", []string{"os", "nope"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		o := New()
+		o.Hide["kernel"] = true
+		o.Modules = tt.modules
+
+		got, err := o.modules()
+		if (err != nil) != tt.err {
+			t.Errorf("%s: got error %v, want error %v", tt.name, err, tt.err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// the modules override the hidden fields
+	o := New()
+	o.Hide["kernel"] = true
+	if o.shown("kernel") || !o.shown("os") {
+		t.Errorf("got kernel shown %v and os %v, want the kernel hidden", o.shown("kernel"), o.shown("os"))
+	}
+	o.Modules = []string{"kernel,separator"}
+	if !o.shown("kernel") || o.shown("os") {
+		t.Errorf("got kernel shown %v and os %v, want the kernel alone", o.shown("kernel"), o.shown("os"))
+	}
+}
+
+func TestFormattedInfoModules(t *testing.T) {
+	si := infoOf(t, map[string]interface{}{
+		"os":     osInfo{"Arch Linux", "x86_64"},
+		"kernel": "6.9.1-arch1-1",
+	})
+	si.TimedOut = []string{"hostname"}
+
+	o := New()
+	o.Colors = Colors{}
+	// the cpu wasn't collected, the hostname timed out
+	o.Modules = []string{"kernel", "separator", "os", "blank", "cpu", "hostname", "separator"}
+
+	got, err := getFormattedInfo(o, si)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"Kernel: 6.9.1-arch1-1",
+		"---------------------",
+		"OS: Arch Linux x86_64",
+		"",
+		"Hostname: " + timeoutText,
+		"---------------------",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
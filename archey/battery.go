@@ -0,0 +1,116 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"path/filepath"
+	"strconv"
+)
+
+const sysPowerSupply = "/sys/class/power_supply"
+
+// Battery is a system battery found in /sys/class/power_supply
+type Battery struct {
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity"` // percent
+	// Status is one of Charging, Discharging, Full, Not charging or Unknown
+	Status string `json:"status"`
+	// Health is the full capacity relative to the design capacity in
+	// percent, it's left 0 when the battery doesn't report it
+	Health float64 `json:"health"`
+	// Remaining is the time left until the battery is empty
+	// or full in seconds, 0 when it can't be estimated
+	Remaining uint64 `json:"remaining"`
+}
+
+// getBatteries returns the batteries of the system mounted at root, leaving
+// out the ones of peripherals such as wireless mice
+func getBatteries(root string) ([]Battery, error) {
+	supplies, err := filepath.Glob(filepath.Join(root, sysPowerSupply, "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	batteries := []Battery{}
+	for _, dir := range supplies {
+		read := func(name string) string {
+			s, _ := readFirstLine(filepath.Join(dir, name))
+			return s
+		}
+		readFloat := func(name string) float64 {
+			n, _ := strconv.ParseFloat(read(name), 64)
+			return n
+		}
+
+		if read("type") != "Battery" || read("scope") == "Device" {
+			continue
+		}
+
+		b := Battery{Name: filepath.Base(dir), Status: read("status")}
+		if b.Status == "" {
+			b.Status = "Unknown"
+		}
+
+		// batteries report either energy in µWh and power in µW
+		// or charge in µAh and current in µA
+		now, full, design, rate := readFloat("energy_now"), readFloat("energy_full"),
+			readFloat("energy_full_design"), readFloat("power_now")
+		if full == 0 {
+			now, full, design, rate = readFloat("charge_now"), readFloat("charge_full"),
+				readFloat("charge_full_design"), readFloat("current_now")
+		}
+
+		if c := read("capacity"); c != "" {
+			b.Capacity, _ = strconv.Atoi(c)
+		} else if full > 0 {
+			b.Capacity = int(now / full * 100)
+		}
+		if full > 0 && design > 0 {
+			b.Health = full / design * 100
+		}
+
+		// some drivers report a negative rate while discharging
+		if rate < 0 {
+			rate = -rate
+		}
+		if rate > 0 {
+			switch b.Status {
+			case "Discharging":
+				b.Remaining = uint64(now / rate * 3600)
+			case "Charging":
+				if full > now {
+					b.Remaining = uint64((full - now) / rate * 3600)
+				}
+			}
+		}
+
+		batteries = append(batteries, b)
+	}
+
+	return batteries, nil
+}
+
+// GetBatteries returns the batteries of the system
+func GetBatteries() ([]Battery, error) {
+	return getBatteries("/")
+}
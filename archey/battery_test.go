@@ -0,0 +1,67 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGetBatteries(t *testing.T) {
+	tests := []struct {
+		root string
+		want []Battery
+	}{
+		// the mains and the battery of the mouse are left out, BAT0 reports
+		// energy and power and BAT1 charge and a negative current
+		{sysroot, []Battery{
+			{Name: "BAT0", Capacity: 87, Status: "Discharging", Health: 87.72, Remaining: 3 * 3600},
+			{Name: "BAT1", Capacity: 50, Status: "Charging", Remaining: 2 * 3600},
+		}},
+		// without capacity and status files, and a full battery still charging
+		{"testdata/battery", []Battery{
+			{Name: "BAT0", Capacity: 75, Status: "Unknown"},
+			{Name: "BAT1", Capacity: 100, Status: "Charging", Health: 85.71},
+		}},
+		{"testdata/missing", []Battery{}},
+	}
+
+	for _, tt := range tests {
+		got, err := getBatteries(tt.root)
+		if err != nil {
+			t.Errorf("%s: %v", tt.root, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.root, got, tt.want)
+			continue
+		}
+		for i, b := range got {
+			want := tt.want[i]
+			if b.Name != want.Name || b.Capacity != want.Capacity || b.Status != want.Status ||
+				b.Remaining != want.Remaining || math.Abs(b.Health-want.Health) > 0.01 {
+				t.Errorf("%s: got %+v, want %+v", tt.root, b, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ways the logo body can be colored
+const (
+	bodyPlaceholders = iota // the Nth color for ${cN}
+	bodyVertical            // a gradient from the top to the bottom
+	bodyHorizontal          // a gradient from the left to the right
+	bodyLines               // a color for each range of lines
+)
+
+var ErrInvalidBodyColor = func(v, reason string) error {
+	return fmt.Errorf("invalid body color '%s': %s", v, reason)
+}
+
+// body is a parsed body color
+type body struct {
+	kind int
+	// colors of the placeholders
+	colors []string
+	// stops of the gradients
+	stops [][3]int
+	// colors of the line ranges
+	lines []lineColor
+}
+
+// lineColor colors the logo lines from and to, counting from 1.
+// A to of 0 goes on until the last line.
+type lineColor struct {
+	from, to int
+	color    string
+}
+
+// parseBody parses a body color, which is one of
+//
+//	red+h,green           the colors of ${c1}, ${c2}, ...
+//	vertical(#f00, #00f)  a gradient from the top to the bottom
+//	horizontal(#f00, ...) a gradient from the left to the right
+//	lines(1-6=red, 7-=b)  the colors of ranges of lines, the others
+//	                      keep the default colors
+//
+// Gradients take two or more #rrggbb, rgb() or hsl() stops.
+func parseBody(v string) (*body, error) {
+	v = strings.TrimSpace(v)
+
+	fn, args := "", v
+	if i := strings.IndexByte(v, '('); i >= 0 && strings.HasSuffix(v, ")") {
+		switch name := strings.TrimSpace(v[:i]); name {
+		case "vertical", "horizontal", "lines":
+			fn, args = name, v[i+1:len(v)-1]
+		}
+	}
+
+	var items []string
+	for _, item := range splitColors(args) {
+		if item == "" {
+			return nil, ErrInvalidBodyColor(v, "empty color")
+		}
+		items = append(items, item)
+	}
+
+	switch fn {
+	case "vertical", "horizontal":
+		if len(items) < 2 {
+			return nil, ErrInvalidBodyColor(v, "a gradient takes at least two colors")
+		}
+
+		b := &body{kind: bodyVertical}
+		if fn == "horizontal" {
+			b.kind = bodyHorizontal
+		}
+		for _, item := range items {
+			r, g, bl, ok, err := parseRGB(item)
+			if err != nil {
+				return nil, ErrInvalidBodyColor(v, err.Error())
+			}
+			if !ok {
+				return nil, ErrInvalidBodyColor(v,
+					"gradient colors must be #rrggbb, rgb() or hsl(), not '"+item+"'")
+			}
+			b.stops = append(b.stops, [3]int{r, g, bl})
+		}
+		return b, nil
+	case "lines":
+		b := &body{kind: bodyLines}
+		for _, item := range items {
+			lc, err := parseLineColor(item)
+			if err != nil {
+				return nil, ErrInvalidBodyColor(v, err.Error())
+			}
+			b.lines = append(b.lines, lc)
+		}
+		return b, nil
+	}
+
+	for _, item := range items {
+		if err := validateStyle(item); err != nil {
+			return nil, ErrInvalidBodyColor(v, err.Error())
+		}
+	}
+	return &body{kind: bodyPlaceholders, colors: items}, nil
+}
+
+// parseLineColor parses a range of lines and its color, e.g. 1-6=red
+func parseLineColor(s string) (lineColor, error) {
+	fields := strings.SplitN(s, "=", 2)
+	if len(fields) != 2 {
+		return lineColor{}, fmt.Errorf("expected lines=color, not '%s'", s)
+	}
+
+	lc := lineColor{color: strings.TrimSpace(fields[1])}
+	if err := validateStyle(lc.color); err != nil {
+		return lineColor{}, err
+	}
+
+	var err error
+	bounds := strings.SplitN(strings.TrimSpace(fields[0]), "-", 2)
+	if lc.from, err = strconv.Atoi(bounds[0]); err != nil || lc.from < 1 {
+		return lineColor{}, fmt.Errorf("invalid line '%s'", bounds[0])
+	}
+
+	switch {
+	case len(bounds) == 1:
+		lc.to = lc.from
+	case bounds[1] == "":
+		lc.to = 0
+	default:
+		if lc.to, err = strconv.Atoi(bounds[1]); err != nil || lc.to < lc.from {
+			return lineColor{}, fmt.Errorf("invalid line range '%s'", fields[0])
+		}
+	}
+
+	return lc, nil
+}
+
+// paint colors the lines of the logo art
+func (b *body) paint(art []string, depth int) ([]string, error) {
+	painted := make([]string, len(art))
+
+	switch b.kind {
+	case bodyVertical:
+		for i, line := range art {
+			code, err := b.gradientCode(i, len(art), depth)
+			if err != nil {
+				return nil, err
+			}
+			painted[i] = code + colorPlaceholder.ReplaceAllString(line, "")
+		}
+		return painted, nil
+	case bodyHorizontal:
+		width := 0
+		for _, line := range art {
+			if w := visibleWidth(line); w > width {
+				width = w
+			}
+		}
+
+		for i, line := range art {
+			var sb strings.Builder
+			for x, c := range []rune(colorPlaceholder.ReplaceAllString(line, "")) {
+				// spaces look the same in any color
+				if !unicode.IsSpace(c) {
+					code, err := b.gradientCode(x, width, depth)
+					if err != nil {
+						return nil, err
+					}
+					sb.WriteString(code)
+				}
+				sb.WriteRune(c)
+			}
+			painted[i] = sb.String()
+		}
+		return painted, nil
+	}
+
+	colors := b.colors
+	if b.kind == bodyLines {
+		colors = []string{defBodyColorUpper, defBodyColorLower}
+	}
+
+	codes := make([]string, len(colors))
+	for i, color := range colors {
+		var err error
+		if codes[i], err = colorCode(color, depth); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, line := range art {
+		painted[i] = colorize(line, codes)
+
+		for _, lc := range b.lines {
+			if i+1 >= lc.from && (lc.to == 0 || i+1 <= lc.to) {
+				code, err := colorCode(lc.color, depth)
+				if err != nil {
+					return nil, err
+				}
+				painted[i] = code + colorPlaceholder.ReplaceAllString(line, "")
+			}
+		}
+	}
+
+	return painted, nil
+}
+
+// gradientCode returns the escape code of the color
+// at position pos of n along the gradient
+func (b *body) gradientCode(pos, n, depth int) (string, error) {
+	t := 0.0
+	if n > 1 {
+		t = float64(pos) / float64(n-1)
+	}
+
+	// the stops are evenly spaced
+	segments := len(b.stops) - 1
+	seg := int(t * float64(segments))
+	if seg >= segments {
+		seg = segments - 1
+	}
+	local := t*float64(segments) - float64(seg)
+
+	from, to := b.stops[seg], b.stops[seg+1]
+	var c [3]int
+	for i := range c {
+		c[i] = from[i] + int(math.Round(float64(to[i]-from[i])*local))
+	}
+
+	return colorCode(fmt.Sprintf("#%02x%02x%02x", c[0], c[1], c[2]), depth)
+}
@@ -0,0 +1,103 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBody(t *testing.T) {
+	tests := []struct {
+		v    string
+		want *body
+	}{
+		{"red+h,green", &body{kind: bodyPlaceholders, colors: []string{"red+h", "green"}}},
+		{" red+h, green ", &body{kind: bodyPlaceholders, colors: []string{"red+h", "green"}}},
+		{"vertical(#1793d1, #ffffff)", &body{kind: bodyVertical,
+			stops: [][3]int{{0x17, 0x93, 0xd1}, {0xff, 0xff, 0xff}}}},
+		{"horizontal(rgb(255, 0, 0),hsl(240, 100%, 50%))", &body{kind: bodyHorizontal,
+			stops: [][3]int{{255, 0, 0}, {0, 0, 255}}}},
+		{"lines(1-6=red, 7-=green+h)", &body{kind: bodyLines,
+			lines: []lineColor{{1, 6, "red"}, {7, 0, "green+h"}}}},
+	}
+
+	for _, tt := range tests {
+		b, err := parseBody(tt.v)
+		if err != nil {
+			t.Errorf("%q: %v", tt.v, err)
+			continue
+		}
+		if !reflect.DeepEqual(b, tt.want) {
+			t.Errorf("%q: got %+v, want %+v", tt.v, b, tt.want)
+		}
+	}
+
+	for _, v := range []string{"vertical(#1793d1,,#ffffff)", "vertical(#1793d1)",
+		"vertical(red, blue)", "red,", "lines(6-1=red)", "nocolor"} {
+		if b, err := parseBody(v); err == nil {
+			t.Errorf("%q: got %+v, want an error", v, b)
+		}
+	}
+}
+
+func TestRenderInfoBodyColor(t *testing.T) {
+	setenv(t, map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"})
+
+	render := func(body ...string) string {
+		o := New()
+		o.Logo = "arch"
+		o.Colors.Body = body
+		info, err := o.RenderInfo(SystemInfo{})
+		if err != nil {
+			t.Fatalf("%q: %v", body, err)
+		}
+		return info
+	}
+
+	// a string of the config, an array of the config and the flag,
+	// which is split on its commas, give the same colors
+	forms := [][][]string{
+		{
+			{"vertical(#1793d1, #ffffff)"},
+			{"vertical(#1793d1", " #ffffff)"},
+		},
+		{
+			{"red+h, green"},
+			{"red+h", "green"},
+		},
+	}
+
+	for _, f := range forms {
+		want := render(f[0]...)
+		for _, body := range f[1:] {
+			if got := render(body...); got != want {
+				t.Errorf("%q renders differently than %q", body, f[0])
+			}
+		}
+	}
+
+	if render("vertical(#1793d1, #ffffff)") == render("red+h, green") {
+		t.Error("the gradient renders like the placeholder colors")
+	}
+}
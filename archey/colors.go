@@ -0,0 +1,362 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mgutz/ansi"
+)
+
+// number of colors the terminal can display
+const (
+	depth16   = 16
+	depth256  = 256
+	depthTrue = 1 << 24
+)
+
+// disables the 24-bit escape sequences along with mgutz/ansi's ones
+var plain = false
+
+var (
+	hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	rgbColor = regexp.MustCompile(`^rgb\(\s*(\d+)\s*,\s*(\d+)\s*,\s*(\d+)\s*\)$`)
+	hslColor = regexp.MustCompile(`^hsl\(\s*(\d+(?:\.\d+)?)\s*,\s*(\d+(?:\.\d+)?)%?\s*,\s*(\d+(?:\.\d+)?)%?\s*\)$`)
+)
+
+// color and cursor escape sequences, see StripColors
+var escapeSequence = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// the 16 colors as xterm displays them, with their mgutz/ansi names
+var basicColors = []struct {
+	name    string
+	r, g, b int
+}{
+	{"black", 0, 0, 0},
+	{"red", 205, 0, 0},
+	{"green", 0, 205, 0},
+	{"yellow", 205, 205, 0},
+	{"blue", 0, 0, 238},
+	{"magenta", 205, 0, 205},
+	{"cyan", 0, 205, 205},
+	{"white", 229, 229, 229},
+	{"black+h", 127, 127, 127},
+	{"red+h", 255, 0, 0},
+	{"green+h", 0, 255, 0},
+	{"yellow+h", 255, 255, 0},
+	{"blue+h", 92, 92, 255},
+	{"magenta+h", 255, 0, 255},
+	{"cyan+h", 0, 255, 255},
+	{"white+h", 255, 255, 255},
+}
+
+// levels of each component in the 6x6x6 cube of the 256 colors
+var cubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+var ErrInvalidColor = func(c string) error {
+	return fmt.Errorf("invalid color '%s'", c)
+}
+
+// colorDepth returns the number of colors the terminal supports
+// based on the COLORTERM and TERM variables
+func colorDepth(getenv func(string) string) int {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return depthTrue
+	}
+	if strings.Contains(getenv("TERM"), "256color") {
+		return depth256
+	}
+	return depth16
+}
+
+// parseRGB parses a #rgb, #rrggbb, rgb(r,g,b) or hsl(h,s%,l%) color.
+// ok is false when c is none of them, e.g. a 256-color index.
+func parseRGB(c string) (r, g, b int, ok bool, err error) {
+	c = strings.TrimSpace(c)
+
+	switch {
+	case hexColor.MatchString(c):
+		hex := c[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		n, _ := strconv.ParseUint(hex, 16, 32)
+		return int(n >> 16 & 0xff), int(n >> 8 & 0xff), int(n & 0xff), true, nil
+	case rgbColor.MatchString(c):
+		m := rgbColor.FindStringSubmatch(c)
+		var v [3]int
+		for i := range v {
+			v[i], _ = strconv.Atoi(m[i+1])
+			if v[i] > 255 {
+				return 0, 0, 0, false, ErrInvalidColor(c)
+			}
+		}
+		return v[0], v[1], v[2], true, nil
+	case hslColor.MatchString(c):
+		m := hslColor.FindStringSubmatch(c)
+		h, _ := strconv.ParseFloat(m[1], 64)
+		s, _ := strconv.ParseFloat(m[2], 64)
+		l, _ := strconv.ParseFloat(m[3], 64)
+		if h > 360 || s > 100 || l > 100 {
+			return 0, 0, 0, false, ErrInvalidColor(c)
+		}
+		r, g, b = hslToRGB(h, s/100, l/100)
+		return r, g, b, true, nil
+	case strings.HasPrefix(c, "#") || strings.HasPrefix(c, "rgb(") || strings.HasPrefix(c, "hsl("):
+		return 0, 0, 0, false, ErrInvalidColor(c)
+	}
+
+	return 0, 0, 0, false, nil
+}
+
+// hslToRGB converts a hue in degrees and a saturation
+// and lightness between 0 and 1 to RGB
+func hslToRGB(h, s, l float64) (int, int, int) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	round := func(v float64) int { return int(math.Round((v + m) * 255)) }
+	return round(r), round(g), round(b)
+}
+
+// distance returns the squared distance between two colors
+func distance(r1, g1, b1, r2, g2, b2 int) int {
+	return (r1-r2)*(r1-r2) + (g1-g2)*(g1-g2) + (b1-b2)*(b1-b2)
+}
+
+// nearest256 returns the index of the 256-color palette closest to the
+// color, picking from the 6x6x6 cube and the grayscale ramp
+func nearest256(r, g, b int) int {
+	level := func(v int) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if abs(v-l) < abs(v-cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+
+	ri, gi, bi := level(r), level(g), level(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := distance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	// the 24 grays go from 8 to 238 in steps of 10
+	gray := (r+g+b)/3 - 8
+	if gray < 0 {
+		gray = 0
+	}
+	gi24 := (gray + 5) / 10
+	if gi24 > 23 {
+		gi24 = 23
+	}
+	v := 8 + gi24*10
+	if distance(r, g, b, v, v, v) < cubeDist {
+		return 232 + gi24
+	}
+	return cube
+}
+
+// nearest16 returns the mgutz/ansi name of the basic color closest to the color
+func nearest16(r, g, b int) string {
+	best := 0
+	for i, c := range basicColors {
+		if distance(r, g, b, c.r, c.g, c.b) < distance(r, g, b,
+			basicColors[best].r, basicColors[best].g, basicColors[best].b) {
+			best = i
+		}
+	}
+	return basicColors[best].name
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// attributes mgutz/ansi understands after the + of a color
+const colorAttributes = "bBdhisu"
+
+// validateStyle checks that every part of the color style is either empty,
+// a color known to mgutz/ansi or a #rrggbb, rgb() or hsl() color, with
+// known attributes
+func validateStyle(style string) error {
+	switch style {
+	case "", resetColor, "off":
+		return nil
+	}
+
+	for _, part := range strings.SplitN(style, ":", 2) {
+		color, attrs := part, ""
+		if i := strings.IndexByte(part, '+'); i >= 0 {
+			color, attrs = part[:i], part[i+1:]
+		}
+
+		for _, a := range attrs {
+			if !strings.ContainsRune(colorAttributes, a) {
+				return ErrInvalidColor(style)
+			}
+		}
+
+		if _, ok := ansi.Colors[color]; ok || color == "" {
+			continue
+		}
+		if _, _, _, ok, err := parseRGB(color); err != nil || !ok {
+			return ErrInvalidColor(style)
+		}
+	}
+
+	return nil
+}
+
+// colorCode returns the escape sequence of the color style, which is in the
+// mgutz/ansi format foreground+attributes:background+attributes. Foreground
+// and background can also be #rrggbb, rgb() or hsl() colors, which are
+// displayed as is on terminals supporting 24-bit colors and converted to the
+// closest of the colors the terminal supports otherwise.
+func colorCode(style string, depth int) (string, error) {
+	if err := validateStyle(style); err != nil {
+		return "", err
+	}
+	if plain {
+		return "", nil
+	}
+
+	var truecolor []string
+	parts := strings.SplitN(style, ":", 2)
+	for i, part := range parts {
+		color, attrs := part, ""
+		if j := strings.IndexByte(part, '+'); j >= 0 {
+			color, attrs = part[:j], part[j:]
+		}
+
+		r, g, b, ok, err := parseRGB(color)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			continue
+		}
+
+		switch depth {
+		case depthTrue:
+			// mgutz/ansi sets the attributes and the
+			// 24-bit color follows in its own sequence
+			base := 38
+			if i == 1 {
+				base = 48
+			}
+			truecolor = append(truecolor, fmt.Sprintf("\033[%d;2;%d;%d;%dm", base, r, g, b))
+			color = "default"
+		case depth256:
+			color = strconv.Itoa(nearest256(r, g, b))
+		default:
+			color = nearest16(r, g, b)
+			// bright colors are named with the h attribute
+			if strings.HasSuffix(color, "+h") {
+				color = strings.TrimSuffix(color, "+h")
+				if attrs == "" {
+					attrs = "+"
+				}
+				attrs += "h"
+			}
+		}
+		parts[i] = color + attrs
+	}
+
+	return ansi.ColorCode(strings.Join(parts, ":")) + strings.Join(truecolor, ""), nil
+}
+
+// StripColors removes the escape sequences from the rendered info s,
+// for when it's displayed by something other than a terminal
+func StripColors(s string) string {
+	return escapeSequence.ReplaceAllString(s, "")
+}
+
+// colorFunc returns a function wrapping its argument
+// in the escape sequence of the color style
+func colorFunc(style string, depth int) (func(string) string, error) {
+	code, err := colorCode(style, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	reset := ansi.ColorCode(resetColor)
+	return func(s string) string {
+		if code == "" || s == "" {
+			return s
+		}
+		return code + s + reset
+	}, nil
+}
+
+// splitColors splits a comma separated list of colors,
+// leaving the commas of rgb() and hsl() colors alone
+func splitColors(s string) []string {
+	var colors []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				colors = append(colors, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(colors, strings.TrimSpace(s[start:]))
+}
+
+// termColorDepth returns the number of colors the terminal supports
+func termColorDepth() int {
+	return colorDepth(os.Getenv)
+}
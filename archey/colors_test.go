@@ -0,0 +1,172 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"testing"
+
+	"github.com/mgutz/ansi"
+)
+
+func TestParseRGB(t *testing.T) {
+	tests := []struct {
+		color   string
+		r, g, b int
+		ok      bool
+		err     bool
+	}{
+		{"#1793d1", 0x17, 0x93, 0xd1, true, false},
+		{"#1793D1", 0x17, 0x93, 0xd1, true, false},
+		{"#f80", 0xff, 0x88, 0x00, true, false},
+		{" #fff ", 255, 255, 255, true, false},
+		{"rgb(23, 147, 209)", 23, 147, 209, true, false},
+		{"hsl(0, 100%, 50%)", 255, 0, 0, true, false},
+		{"hsl(200, 80, 45)", 23, 145, 207, true, false},
+		{"#12", 0, 0, 0, false, true},
+		{"#1234", 0, 0, 0, false, true},
+		{"#ggg", 0, 0, 0, false, true},
+		{"#1793d1ff", 0, 0, 0, false, true},
+		{"rgb(256, 0, 0)", 0, 0, 0, false, true},
+		{"rgb(1, 2)", 0, 0, 0, false, true},
+		{"hsl(361, 50%, 50%)", 0, 0, 0, false, true},
+		{"hsl(0, 101%, 50%)", 0, 0, 0, false, true},
+		{"red+h", 0, 0, 0, false, false},
+		{"111", 0, 0, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		r, g, b, ok, err := parseRGB(tt.color)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: got error %v, want error %v", tt.color, err, tt.err)
+			continue
+		}
+		if r != tt.r || g != tt.g || b != tt.b || ok != tt.ok {
+			t.Errorf("%q: got %d, %d, %d, %v, want %d, %d, %d, %v",
+				tt.color, r, g, b, ok, tt.r, tt.g, tt.b, tt.ok)
+		}
+	}
+}
+
+func TestNearest256(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, g, b int
+		want    int
+	}{
+		{"black is in the cube", 0, 0, 0, 16},
+		{"white is in the cube", 255, 255, 255, 231},
+		{"red", 255, 0, 0, 196},
+		{"cube color", 95, 135, 175, 67},
+		{"gray in the cube", 175, 175, 175, 145},
+		{"darkest gray", 8, 8, 8, 232},
+		{"lightest gray", 238, 238, 238, 255},
+		{"gray between cube levels", 128, 128, 128, 244},
+		{"gray closer to the ramp", 100, 100, 100, 241},
+		{"almost gray", 100, 104, 100, 241},
+	}
+
+	for _, tt := range tests {
+		if got := nearest256(tt.r, tt.g, tt.b); got != tt.want {
+			t.Errorf("%s: nearest256(%d, %d, %d) = %d, want %d",
+				tt.name, tt.r, tt.g, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNearest16(t *testing.T) {
+	tests := []struct {
+		r, g, b int
+		want    string
+	}{
+		{0, 0, 0, "black"},
+		{255, 255, 255, "white+h"},
+		{0x17, 0x93, 0xd1, "cyan"},
+		{200, 10, 10, "red"},
+		{120, 120, 130, "black+h"},
+	}
+
+	for _, tt := range tests {
+		if got := nearest16(tt.r, tt.g, tt.b); got != tt.want {
+			t.Errorf("nearest16(%d, %d, %d) = %s, want %s", tt.r, tt.g, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestColorDepth(t *testing.T) {
+	tests := []struct {
+		colorterm, term string
+		want            int
+	}{
+		{"truecolor", "xterm", depthTrue},
+		{"24bit", "xterm", depthTrue},
+		{"TrueColor", "", depthTrue},
+		{"", "xterm-256color", depth256},
+		{"yes", "tmux-256color", depth256},
+		{"", "xterm", depth16},
+		{"", "", depth16},
+	}
+
+	for _, tt := range tests {
+		env := map[string]string{"COLORTERM": tt.colorterm, "TERM": tt.term}
+		if got := colorDepth(func(k string) string { return env[k] }); got != tt.want {
+			t.Errorf("COLORTERM=%q TERM=%q: got %d colors, want %d",
+				tt.colorterm, tt.term, got, tt.want)
+		}
+	}
+}
+
+func TestColorCode(t *testing.T) {
+	tests := []struct {
+		style string
+		depth int
+		want  string
+	}{
+		{"#ff0000", depthTrue, ansi.ColorCode("default") + "\033[38;2;255;0;0m"},
+		{"#ff0000", depth256, ansi.ColorCode("196")},
+		{"#ff0000", depth16, ansi.ColorCode("red+h")},
+		{"#ff0000+b:#000080", depthTrue,
+			ansi.ColorCode("default+b:default") + "\033[38;2;255;0;0m\033[48;2;0;0;128m"},
+		{"#ff0000+b:#000080", depth256, ansi.ColorCode("196+b:18")},
+		{"#ff0000+b:#000080", depth16, ansi.ColorCode("red+bh:blue")},
+		{"rgb(0, 205, 0)", depth16, ansi.ColorCode("green")},
+		{"111", depth16, ansi.ColorCode("111")},
+		{"red+h", depthTrue, ansi.ColorCode("red+h")},
+	}
+
+	for _, tt := range tests {
+		got, err := colorCode(tt.style, tt.depth)
+		if err != nil {
+			t.Errorf("%q at %d colors: %v", tt.style, tt.depth, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q at %d colors: got %q, want %q", tt.style, tt.depth, got, tt.want)
+		}
+	}
+
+	for _, style := range []string{"#12", "rgb(300, 0, 0)", "red+x", "nocolor"} {
+		if _, err := colorCode(style, depthTrue); err == nil {
+			t.Errorf("%q: got no error", style)
+		}
+	}
+}
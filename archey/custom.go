@@ -0,0 +1,127 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCustom = func(label, reason string) error {
+	return fmt.Errorf("invalid custom line '%s': %s", label, reason)
+}
+
+// Custom is a user-defined line whose text is either static,
+// read from a file or printed by a shell command
+type Custom struct {
+	Label   string
+	Text    string
+	File    string
+	Command string
+	// Timeout limits how long Command may run,
+	// zero means the timeout of the custom field
+	Timeout time.Duration
+}
+
+// CustomValue is the collected text of a custom line
+type CustomValue struct {
+	Label    string `json:"label"`
+	Text     string `json:"text"`
+	Error    string `json:"error,omitempty"`
+	TimedOut bool   `json:"timed_out,omitempty"`
+}
+
+// validate checks that c has a label and exactly one source of text
+func (c Custom) validate() error {
+	if c.Label == "" {
+		return ErrInvalidCustom(c.Label, "missing label")
+	}
+
+	sources := 0
+	for _, s := range []string{c.Text, c.File, c.Command} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return ErrInvalidCustom(c.Label, "exactly one of text, file or command must be set")
+	}
+
+	return nil
+}
+
+// collect returns the text of the custom line, commands are killed
+// once they run for longer than timeout
+func (c Custom) collect(timeout time.Duration) (string, error) {
+	switch {
+	case c.File != "":
+		b, err := ioutil.ReadFile(expandHome(c.File))
+		if err != nil {
+			return "", err
+		}
+		return firstLine(string(b)), nil
+	case c.Command != "":
+		if c.Timeout > 0 {
+			timeout = c.Timeout
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// children of the shell can keep its output open after it's
+		// killed, so the command isn't waited for past its timeout
+		out, err := withTimeout("custom "+c.Command, timeout, func() (interface{}, error) {
+			return exec.CommandContext(ctx, "sh", "-c", c.Command).Output()
+		})
+		if err != nil {
+			return "", err
+		}
+		return firstLine(string(out.([]byte))), nil
+	}
+
+	return c.Text, nil
+}
+
+// firstLine returns the first non-empty line of s
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// expandHome replaces a leading ~ of path with the home directory
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		return filepath.Join(os.Getenv("HOME"), path[1:])
+	}
+	return path
+}
@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCustomValidate(t *testing.T) {
+	tests := []struct {
+		custom Custom
+		err    bool
+	}{
+		{Custom{Label: "Motd", Text: "hello"}, false},
+		{Custom{Label: "Motd", File: "/etc/motd"}, false},
+		{Custom{Label: "Motd", Command: "cat /etc/motd"}, false},
+		{Custom{Text: "hello"}, true},
+		{Custom{Label: "Motd"}, true},
+		{Custom{Label: "Motd", Text: "hello", Command: "cat /etc/motd"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.custom.validate(); (err != nil) != tt.err {
+			t.Errorf("%+v: got error %v, want error %v", tt.custom, err, tt.err)
+		}
+	}
+
+	o := New()
+	o.Custom = []Custom{{Label: "Motd", Text: "hello"}, {Label: "Broken"}}
+	if _, err := collectCustom(o); err == nil {
+		t.Error("got no error for an invalid custom line")
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	setenv(t, map[string]string{"HOME": "/home/user"})
+
+	tests := []struct {
+		path, want string
+	}{
+		{"~", "/home/user"},
+		{"~/motd", "/home/user/motd"},
+		{"~other/motd", "~other/motd"},
+		{"/etc/~/motd", "/etc/~/motd"},
+		{"motd", "motd"},
+	}
+
+	for _, tt := range tests {
+		if got := expandHome(tt.path); got != tt.want {
+			t.Errorf("expandHome(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCollectCustom(t *testing.T) {
+	home, err := filepath.Abs("testdata/custom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setenv(t, map[string]string{"HOME": home})
+
+	o := New()
+	o.Timeouts["custom"] = 100 * time.Millisecond
+	o.Custom = []Custom{
+		{Label: "Text", Text: "hello"},
+		{Label: "File", File: "~/motd"},
+		{Label: "Empty file", File: "~/empty"},
+		{Label: "Missing", File: "~/missing"},
+		{Label: "Command", Command: "printf '\\n  out  \\nmore\\n'"},
+		{Label: "Silent", Command: "true"},
+		{Label: "Failing", Command: "exit 3"},
+		{Label: "Slow", Command: "sleep 5"},
+		{Label: "Own timeout", Command: "sleep 0.3; echo late", Timeout: 5 * time.Second},
+	}
+
+	start := time.Now()
+	v, err := collectCustom(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("took %v, want the slow command killed", d)
+	}
+
+	got := v.([]CustomValue)
+	want := []CustomValue{
+		{Label: "Text", Text: "hello"},
+		{Label: "File", Text: "Welcome to fixture"},
+		{Label: "Empty file"},
+		{Label: "Missing"},
+		{Label: "Command", Text: "out"},
+		{Label: "Silent"},
+		{Label: "Failing", Error: "exit status 3"},
+		{Label: "Slow", TimedOut: true},
+		{Label: "Own timeout", Text: "late"},
+	}
+
+	// the error of the missing file holds its path
+	if !strings.Contains(got[3].Error, filepath.Join(home, "missing")) {
+		t.Errorf("got error %q, want the expanded path", got[3].Error)
+	}
+	got[3].Error = ""
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	lines, err := formatCustom(o, want[5:8])
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLines := []Line{
+		{Name: "Silent", Text: ""},
+		{Name: "Failing", Text: "error: exit status 3"},
+		{Name: "Slow", Text: timeoutText},
+	}
+	if !reflect.DeepEqual(lines, wantLines) {
+		t.Errorf("got lines %+v, want %+v", lines, wantLines)
+	}
+}
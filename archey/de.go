@@ -0,0 +1,235 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// desktop environment names
+const (
+	deGNOME         = "GNOME"
+	dePlasma        = "Plasma"
+	deKDE           = "KDE"
+	deCinnamon      = "Cinnamon"
+	deMATE          = "MATE"
+	deXfce          = "Xfce"
+	deLXDE          = "LXDE"
+	deLXQt          = "LXQt"
+	deBudgie        = "Budgie"
+	deDeepin        = "Deepin"
+	dePantheon      = "Pantheon"
+	deUnity         = "Unity"
+	deCOSMIC        = "COSMIC"
+	deEnlightenment = "Enlightenment"
+)
+
+// desktopNames maps the lower case names used in XDG_CURRENT_DESKTOP,
+// XDG_SESSION_DESKTOP and DESKTOP_SESSION to desktop environments
+var desktopNames = map[string]string{
+	"gnome":          deGNOME,
+	"gnome-xorg":     deGNOME,
+	"gnome-wayland":  deGNOME,
+	"kde":            dePlasma,
+	"plasma":         dePlasma,
+	"plasmawayland":  dePlasma,
+	"plasmax11":      dePlasma,
+	"x-cinnamon":     deCinnamon,
+	"cinnamon":       deCinnamon,
+	"mate":           deMATE,
+	"xfce":           deXfce,
+	"xfce4":          deXfce,
+	"lxde":           deLXDE,
+	"lxqt":           deLXQt,
+	"budgie":         deBudgie,
+	"budgie-desktop": deBudgie,
+	"deepin":         deDeepin,
+	"dde":            deDeepin,
+	"pantheon":       dePantheon,
+	"unity":          deUnity,
+	"cosmic":         deCOSMIC,
+	"enlightenment":  deEnlightenment,
+}
+
+// desktop environment processes in order of priority, the ones
+// built on top of GNOME components come before GNOME itself
+var desktopProcs = []struct {
+	proc string
+	name string
+}{
+	{"budgie-panel", deBudgie},
+	{"cinnamon-sessio", deCinnamon}, // comm is truncated to 15 characters
+	{"cinnamon", deCinnamon},
+	{"gala", dePantheon},
+	{"unity-panel-ser", deUnity},
+	{"cosmic-session", deCOSMIC},
+	{"startdde", deDeepin},
+	{"dde-session", deDeepin},
+	{"plasmashell", dePlasma},
+	{"ksmserver", deKDE},
+	{"mate-session", deMATE},
+	{"xfce4-session", deXfce},
+	{"lxqt-session", deLXQt},
+	{"lxsession", deLXDE},
+	{"gnome-session-b", deGNOME},
+	{"gnome-session", deGNOME},
+}
+
+// files holding the version of desktop environments
+var (
+	gnomeVersionFile  = "/usr/share/gnome/gnome-version.xml"
+	mateVersionFile   = "/usr/share/mate-about/mate-version.xml"
+	deepinVersionFile = "/etc/deepin-version"
+	plasmaSessions    = []string{
+		"/usr/share/wayland-sessions/plasma.desktop",
+		"/usr/share/wayland-sessions/plasmawayland.desktop",
+		"/usr/share/xsessions/plasma.desktop",
+		"/usr/share/xsessions/plasmax11.desktop",
+	}
+)
+
+// GetDE returns the Desktop Environment name along with its version
+// if it can be found, e.g. GNOME 45.2
+func GetDE() string {
+	return getDE("/", os.Getenv)
+}
+
+// getDE returns the Desktop Environment of the system whose root
+// file system is root. The XDG variables of the session are checked
+// first then the running processes in order of priority.
+func getDE(root string, getenv func(string) string) string {
+	de := deFromEnv(getenv)
+	if de == "" {
+		procs := procNames(filepath.Join(root, procDir))
+		for _, p := range desktopProcs {
+			if procs[p.proc] {
+				de = p.name
+				break
+			}
+		}
+	}
+
+	if de == "" {
+		return "None"
+	}
+
+	if de == dePlasma {
+		// KDE_SESSION_VERSION holds the major version of Plasma
+		v, err := strconv.Atoi(getenv("KDE_SESSION_VERSION"))
+		if err == nil && v < 5 {
+			de = deKDE
+		}
+	}
+
+	if version := deVersion(root, de); version != "" {
+		return de + " " + version
+	}
+	return de
+}
+
+// deFromEnv returns the desktop environment named by the session's
+// XDG_CURRENT_DESKTOP, XDG_SESSION_DESKTOP or DESKTOP_SESSION
+func deFromEnv(getenv func(string) string) string {
+	for _, env := range []string{"XDG_CURRENT_DESKTOP", "XDG_SESSION_DESKTOP", "DESKTOP_SESSION"} {
+		// XDG_CURRENT_DESKTOP is a colon separated list, e.g. ubuntu:GNOME
+		for _, name := range strings.Split(getenv(env), ":") {
+			// DESKTOP_SESSION can be a path to the session file
+			name = strings.ToLower(filepath.Base(strings.TrimSpace(name)))
+			if de, ok := desktopNames[name]; ok {
+				return de
+			}
+		}
+	}
+	return ""
+}
+
+// deVersion returns the version of the desktop environment de
+// installed on the system whose root file system is root
+func deVersion(root, de string) string {
+	switch de {
+	case deGNOME:
+		return readGNOMEVersion(filepath.Join(root, gnomeVersionFile))
+	case deMATE:
+		return readGNOMEVersion(filepath.Join(root, mateVersionFile))
+	case dePlasma:
+		for _, f := range plasmaSessions {
+			v := readKeyValue(filepath.Join(root, f), "X-KDE-PluginInfo-Version")
+			if v != "" {
+				return v
+			}
+		}
+	case deDeepin:
+		return readKeyValue(filepath.Join(root, deepinVersionFile), "Version")
+	}
+	return ""
+}
+
+// readGNOMEVersion reads the version from gnome-version.xml
+// or the file of the same format used by MATE
+func readGNOMEVersion(f string) string {
+	b, err := ioutil.ReadFile(f)
+	if err != nil {
+		return ""
+	}
+
+	var v struct {
+		Platform string `xml:"platform"`
+		Minor    string `xml:"minor"`
+		Micro    string `xml:"micro"`
+	}
+	if err := xml.Unmarshal(b, &v); err != nil {
+		return ""
+	}
+
+	var parts []string
+	for _, p := range []string{v.Platform, v.Minor, v.Micro} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// readKeyValue returns the value of key in the key=value file f
+func readKeyValue(f, key string) string {
+	file, err := os.Open(f)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "=", 2)
+		if len(fields) == 2 && strings.TrimSpace(fields[0]) == key {
+			return strings.TrimSpace(fields[1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetDE(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+		env  map[string]string
+		want string
+	}{
+		{"current desktop list", "gnome",
+			map[string]string{"XDG_CURRENT_DESKTOP": "ubuntu:GNOME"}, "GNOME 45.2"},
+		{"gnome process", "gnome", nil, "GNOME 45.2"},
+		{"budgie before gnome", "budgie", nil, "Budgie"},
+		{"plasma process", "plasma", nil, "Plasma 6.0.5"},
+		{"kde session", "plasma",
+			map[string]string{"XDG_CURRENT_DESKTOP": "KDE"}, "Plasma 6.0.5"},
+		{"kde 4", "plasma",
+			map[string]string{"XDG_CURRENT_DESKTOP": "KDE", "KDE_SESSION_VERSION": "4"}, "KDE"},
+		{"session desktop", "missing",
+			map[string]string{"XDG_SESSION_DESKTOP": "xfce"}, "Xfce"},
+		{"session file", "missing",
+			map[string]string{"DESKTOP_SESSION": "/usr/share/xsessions/cinnamon"}, "Cinnamon"},
+		{"unknown names", "missing",
+			map[string]string{"XDG_CURRENT_DESKTOP": "none+i3", "DESKTOP_SESSION": "lxqt"}, "LXQt"},
+		{"mate version", "versions",
+			map[string]string{"XDG_CURRENT_DESKTOP": "MATE"}, "MATE 1.28.0"},
+		{"deepin version", "versions",
+			map[string]string{"XDG_CURRENT_DESKTOP": "Deepin"}, "Deepin 23"},
+		{"no version", "versions",
+			map[string]string{"XDG_CURRENT_DESKTOP": "GNOME"}, "GNOME"},
+		{"nothing known", "mixed", nil, "None"},
+	}
+
+	for _, tt := range tests {
+		getenv := func(k string) string { return tt.env[k] }
+		if got := getDE(filepath.Join(desktopFixture, tt.root), getenv); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
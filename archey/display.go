@@ -0,0 +1,226 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// length of the EDID base block
+const edidLength = 128
+
+var edidHeader = []byte{0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}
+
+// tag of the EDID display descriptor holding the monitor name
+const edidTagName = 0xfc
+
+// monitor manufacturers by PNP ID
+var edidManufacturers = map[string]string{
+	"ACR": "Acer",
+	"AOC": "AOC",
+	"APP": "Apple",
+	"AUO": "AU Optronics",
+	"AUS": "ASUS",
+	"BNQ": "BenQ",
+	"BOE": "BOE",
+	"CMN": "Innolux",
+	"DEL": "Dell",
+	"ENC": "EIZO",
+	"GSM": "LG",
+	"HWP": "HP",
+	"IVM": "iiyama",
+	"LEN": "Lenovo",
+	"LGD": "LG Display",
+	"MSI": "MSI",
+	"NEC": "NEC",
+	"PHL": "Philips",
+	"SAM": "Samsung",
+	"SDC": "Samsung Display",
+	"SHP": "Sharp",
+	"SNY": "Sony",
+	"VSC": "ViewSonic",
+}
+
+var ErrInvalidEDID = func(reason string) error {
+	return fmt.Errorf("invalid EDID: %s", reason)
+}
+
+// EDID is the part of a monitor's EDID archey-go cares about
+type EDID struct {
+	// Manufacturer is the three letter PNP ID, e.g. DEL
+	Manufacturer string
+	Product      uint16
+	// Name is the monitor name descriptor, e.g. DELL U2720Q
+	Name string
+	// Width and Height are the physical size in millimetres
+	Width  int
+	Height int
+	// resolution and refresh rate of the preferred timing
+	HActive int
+	VActive int
+	Refresh float64
+}
+
+// Display is a monitor plugged into a DRM connector
+type Display struct {
+	// Connector is the name of the connector, e.g. DP-1
+	Connector    string  `json:"connector"`
+	Manufacturer string  `json:"manufacturer"`
+	Model        string  `json:"model"`
+	Width        int     `json:"width_mm"`
+	Height       int     `json:"height_mm"`
+	Resolution   string  `json:"resolution"`
+	Refresh      float64 `json:"refresh"`
+}
+
+// parseEDID parses the base block of an EDID blob
+func parseEDID(b []byte) (*EDID, error) {
+	if len(b) < edidLength {
+		return nil, ErrInvalidEDID("too short")
+	}
+	if !bytes.Equal(b[:len(edidHeader)], edidHeader) {
+		return nil, ErrInvalidEDID("bad header")
+	}
+
+	var sum byte
+	for _, c := range b[:edidLength] {
+		sum += c
+	}
+	if sum != 0 {
+		return nil, ErrInvalidEDID("bad checksum")
+	}
+
+	// three letters of five bits each, 1 being A
+	id := binary.BigEndian.Uint16(b[8:10])
+	e := &EDID{
+		Manufacturer: string([]byte{
+			byte(id>>10&0x1f) + 'A' - 1,
+			byte(id>>5&0x1f) + 'A' - 1,
+			byte(id&0x1f) + 'A' - 1,
+		}),
+		Product: binary.LittleEndian.Uint16(b[10:12]),
+		// the size in centimetres, refined by the detailed timing below
+		Width:  int(b[21]) * 10,
+		Height: int(b[22]) * 10,
+	}
+
+	// four 18 byte descriptors, the first detailed timing is the preferred one
+	preferred := false
+	for i := 54; i < 126; i += 18 {
+		d := b[i : i+18]
+
+		if clock := binary.LittleEndian.Uint16(d[0:2]); clock != 0 {
+			if preferred {
+				continue
+			}
+			preferred = true
+
+			hActive := int(d[2]) | int(d[4]&0xf0)<<4
+			hBlank := int(d[3]) | int(d[4]&0x0f)<<8
+			vActive := int(d[5]) | int(d[7]&0xf0)<<4
+			vBlank := int(d[6]) | int(d[7]&0x0f)<<8
+			e.HActive, e.VActive = hActive, vActive
+			if total := (hActive + hBlank) * (vActive + vBlank); total > 0 {
+				e.Refresh = float64(clock) * 10000 / float64(total)
+			}
+			if w, h := int(d[12])|int(d[14]&0xf0)<<4, int(d[13])|int(d[14]&0x0f)<<8; w > 0 && h > 0 {
+				e.Width, e.Height = w, h
+			}
+			continue
+		}
+
+		if d[3] == edidTagName {
+			// up to 13 characters ended by a newline and padded with spaces
+			name := string(d[5:18])
+			if j := strings.IndexByte(name, '\n'); j >= 0 {
+				name = name[:j]
+			}
+			e.Name = strings.TrimSpace(name)
+		}
+	}
+
+	return e, nil
+}
+
+// getDisplays returns the monitors plugged into the
+// DRM connectors of the system mounted at root
+func getDisplays(root string) ([]Display, error) {
+	connectors, err := filepath.Glob(filepath.Join(root, sysDRM, "card*-*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(connectors)
+
+	displays := []Display{}
+	for _, dir := range connectors {
+		if status, _ := readFirstLine(filepath.Join(dir, "status")); status != "connected" {
+			continue
+		}
+
+		// card0-DP-1 is DP-1 of card0
+		name := filepath.Base(dir)
+		d := Display{Connector: name[strings.IndexByte(name, '-')+1:]}
+
+		// the first mode is the preferred one
+		if mode, err := readFirstLine(filepath.Join(dir, "modes")); err == nil {
+			d.Resolution = mode
+		}
+
+		blob, err := ioutil.ReadFile(filepath.Join(dir, "edid"))
+		if err == nil {
+			if e, err := parseEDID(blob); err == nil {
+				d.Manufacturer = e.Manufacturer
+				if m, ok := edidManufacturers[e.Manufacturer]; ok {
+					d.Manufacturer = m
+				}
+				d.Model = e.Name
+				d.Width, d.Height = e.Width, e.Height
+				if e.HActive > 0 && e.VActive > 0 {
+					d.Resolution = fmt.Sprintf("%dx%d", e.HActive, e.VActive)
+					d.Refresh = math.Round(e.Refresh*100) / 100
+				}
+			}
+		}
+
+		displays = append(displays, d)
+	}
+
+	return displays, nil
+}
+
+// diagonal returns the diagonal of the display in inches
+func (d Display) diagonal() float64 {
+	return math.Hypot(float64(d.Width), float64(d.Height)) / 25.4
+}
+
+// GetDisplays returns the monitors plugged into the DRM connectors
+func GetDisplays() ([]Display, error) {
+	return getDisplays("/")
+}
@@ -0,0 +1,97 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"io/ioutil"
+	"math"
+	"testing"
+)
+
+func readEDID(t *testing.T, f string) []byte {
+	b, err := ioutil.ReadFile("testdata/edid/" + f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestParseEDID(t *testing.T) {
+	tests := []struct {
+		file string
+		want EDID
+	}{
+		// laid out like the kernel's built-in EDIDs, the size in the
+		// detailed timing is more precise than the one in centimetres
+		{"linux-fhd.bin", EDID{Manufacturer: "LNX", Product: 0, Name: "Linux FHD",
+			Width: 508, Height: 285, HActive: 1920, VActive: 1080, Refresh: 60}},
+		// the serial number descriptor comes before the name
+		{"dell-u2715h.bin", EDID{Manufacturer: "DEL", Product: 0xd06d, Name: "DELL U2715H",
+			Width: 597, Height: 336, HActive: 2560, VActive: 1440, Refresh: 59.95}},
+	}
+
+	for _, tt := range tests {
+		e, err := parseEDID(readEDID(t, tt.file))
+		if err != nil {
+			t.Errorf("%s: %v", tt.file, err)
+			continue
+		}
+
+		refresh := e.Refresh
+		e.Refresh = math.Round(e.Refresh*100) / 100
+		if *e != tt.want {
+			t.Errorf("%s: got %+v (refresh %v), want %+v", tt.file, *e, refresh, tt.want)
+		}
+	}
+}
+
+func TestParseEDIDInvalid(t *testing.T) {
+	blob := readEDID(t, "dell-u2715h.bin")
+
+	badChecksum := append([]byte(nil), blob...)
+	badChecksum[127]++
+
+	badHeader := append([]byte(nil), blob...)
+	badHeader[0] = 0xff
+
+	tests := []struct {
+		name string
+		blob []byte
+	}{
+		{"empty", nil},
+		{"truncated", blob[:100]},
+		{"bad checksum", badChecksum},
+		{"bad header", badHeader},
+	}
+
+	for _, tt := range tests {
+		if e, err := parseEDID(tt.blob); err == nil {
+			t.Errorf("%s: got %+v, want an error", tt.name, *e)
+		}
+	}
+
+	// extension blocks after the base block are ignored
+	if _, err := parseEDID(append(blob, make([]byte, 128)...)); err != nil {
+		t.Errorf("with an extension block: %v", err)
+	}
+}
@@ -0,0 +1,751 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexdreptu/sysinfo"
+	utils "github.com/alexdreptu/utils-go"
+)
+
+// bytes in a mebibyte
+const mib = 1024 * 1024
+
+// built-in fields in the order they are displayed
+func init() {
+	Register(builtin("os", "OS", collectOS, formatOS,
+		func(si *SystemInfo, v interface{}) {
+			si.OS = v.(osInfo).name
+			si.Arch = v.(osInfo).arch
+		}))
+	Register(builtin("kernel", "Kernel", collectKernel, nil,
+		func(si *SystemInfo, v interface{}) { si.Kernel = v.(string) }))
+	Register(builtin("user", "User", collectUser, nil,
+		func(si *SystemInfo, v interface{}) { si.User = v.(string) }))
+	Register(builtin("hostname", "Hostname", collectHostname, nil,
+		func(si *SystemInfo, v interface{}) { si.Hostname = v.(string) }))
+	Register(dynamic(builtin("uptime", "Uptime", collectUptime, formatUptime,
+		func(si *SystemInfo, v interface{}) { si.Uptime = v.(uint64) })))
+	Register(builtin("up_since", "Up since", collectUpSince, formatUpSince,
+		func(si *SystemInfo, v interface{}) { si.UpSince = v.(time.Time) }))
+	Register(builtin("wm", "Window Manager", collectWM, nil,
+		func(si *SystemInfo, v interface{}) { si.WM = v.(string) }))
+	Register(builtin("de", "Desktop Environment", collectDE, nil,
+		func(si *SystemInfo, v interface{}) { si.DE = v.(string) }))
+	Register(builtin("gtk2_theme", "GTK2 Theme", collectGTK2(gtkTheme), nil,
+		storeGTK2(func(g *GTK, s string) { g.Theme = s })))
+	Register(builtin("gtk2_icon_theme", "GTK2 Icon Theme", collectGTK2(gtkIcons), nil,
+		storeGTK2(func(g *GTK, s string) { g.Icons = s })))
+	Register(builtin("gtk2_font", "GTK2 Font", collectGTK2(gtkFont), nil,
+		storeGTK2(func(g *GTK, s string) { g.Font = s })))
+	Register(builtin("gtk2_cursor_theme", "GTK2 Cursor Theme", collectGTK2(gtkCursor), nil,
+		storeGTK2(func(g *GTK, s string) { g.Cursor = s })))
+	Register(builtin("gtk3_theme", "GTK3 Theme", collectGTK3(gtkTheme), nil,
+		storeGTK3(func(g *GTK, s string) { g.Theme = s })))
+	Register(builtin("gtk3_icon_theme", "GTK3 Icon Theme", collectGTK3(gtkIcons), nil,
+		storeGTK3(func(g *GTK, s string) { g.Icons = s })))
+	Register(builtin("gtk3_font", "GTK3 Font", collectGTK3(gtkFont), nil,
+		storeGTK3(func(g *GTK, s string) { g.Font = s })))
+	Register(builtin("gtk3_cursor_theme", "GTK3 Cursor Theme", collectGTK3(gtkCursor), nil,
+		storeGTK3(func(g *GTK, s string) { g.Cursor = s })))
+	Register(builtin("terminal", "Terminal", collectTerminal, nil,
+		func(si *SystemInfo, v interface{}) { si.Terminal = v.(string) }))
+
+	// the shell is killed when its version takes too long
+	shell := builtin("shell", "Shell", collectShell, formatShell,
+		func(si *SystemInfo, v interface{}) { si.Shell = v.(*Shell) }).(*provider)
+	shell.selfTimed = true
+	Register(shell)
+
+	Register(builtin("editor", "Editor", collectEditor, nil,
+		func(si *SystemInfo, v interface{}) { si.Editor = v.(string) }))
+	Register(builtin("packages", "Packages", collectPackages, formatPackages,
+		func(si *SystemInfo, v interface{}) { si.Packages = v.([]PackageCount) }))
+	Register(dynamic(builtin("memory", "Memory", collectMemory, formatMemory,
+		func(si *SystemInfo, v interface{}) { si.Memory = v.(*Usage) })))
+	Register(dynamic(builtin("swap", "Swap", collectSwap, formatSwap,
+		func(si *SystemInfo, v interface{}) { si.Swap = v.(*Usage) })))
+	Register(builtin("cpu", "CPU", collectCPU, nil,
+		func(si *SystemInfo, v interface{}) { si.CPU = v.(string) }))
+	Register(builtin("gpu", "GPU", collectGPU, formatGPU,
+		func(si *SystemInfo, v interface{}) { si.GPUs = v.([]GPU) }))
+	Register(builtin("displays", "Display", collectDisplays, formatDisplays,
+		func(si *SystemInfo, v interface{}) { si.Displays = v.([]Display) }))
+	Register(dynamic(builtin("battery", "Battery", collectBattery, formatBattery,
+		func(si *SystemInfo, v interface{}) { si.Batteries = v.([]Battery) })))
+	Register(builtin("network", "Network", collectNetwork, formatNetwork,
+		func(si *SystemInfo, v interface{}) { si.Network = v.(*Network) }))
+	Register(dynamic(builtin("root", "Root", collectFS("/"), formatFS("/root"),
+		func(si *SystemInfo, v interface{}) { si.Root = v.(*Usage) })))
+	Register(dynamic(builtin("home", "Home", collectFS("/home"), formatFS("/home"),
+		func(si *SystemInfo, v interface{}) { si.Home = v.(*Usage) })))
+
+	// every path is given the timeout on its own
+	paths := builtin("paths", "Paths", collectPaths, unlabeled(formatPaths),
+		func(si *SystemInfo, v interface{}) { si.Paths = v.([]PathUsage) }).(*provider)
+	paths.selfTimed = true
+	paths.dynamic = true
+	Register(paths)
+
+	// every command is given its own timeout
+	custom := builtin("custom", "Custom", collectCustom, unlabeled(formatCustom),
+		func(si *SystemInfo, v interface{}) { si.Custom = v.([]CustomValue) }).(*provider)
+	custom.selfTimed = true
+	Register(custom)
+}
+
+type osInfo struct {
+	name string
+	arch string
+}
+
+func collectOS(o *Options) (interface{}, error) {
+	node := sysinfo.Node{}
+	if err := node.Get(); err != nil {
+		return nil, err
+	}
+
+	if o.Sysroot == "" {
+		return osInfo{name: node.OSName, arch: node.Machine}, nil
+	}
+
+	// the architecture comes from the running kernel
+	// which is shared with the system under sysroot
+	osr, err := readOSRelease(o.paths(osReleaseFiles)...)
+	if err != nil {
+		return nil, err
+	}
+
+	name := osr["PRETTY_NAME"]
+	if name == "" {
+		name = osr["NAME"]
+	}
+	return osInfo{name: name, arch: node.Machine}, nil
+}
+
+func formatOS(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		osName := v.(osInfo).name
+		if !o.NoArch {
+			osName += " " + v.(osInfo).arch
+		}
+		return []Line{{Name: label, Text: osName}}, nil
+	}
+}
+
+func collectKernel(o *Options) (interface{}, error) {
+	if o.Sysroot != "" {
+		return readFirstLine(o.path(procOSRelease))
+	}
+
+	node := sysinfo.Node{}
+	if err := node.Get(); err != nil {
+		return nil, err
+	}
+	return node.Release, nil
+}
+
+func collectUser(o *Options) (interface{}, error) {
+	usr, err := user.Current()
+	if err != nil {
+		return nil, err
+	}
+	return usr.Username, nil
+}
+
+func collectHostname(o *Options) (interface{}, error) {
+	if o.Sysroot != "" {
+		hostname, err := readFirstLine(o.path(etcHostname))
+		if err != nil {
+			return readFirstLine(o.path(procHostname))
+		}
+		return hostname, nil
+	}
+
+	node := sysinfo.Node{}
+	if err := node.Get(); err != nil {
+		return nil, err
+	}
+	return node.NodeName, nil
+}
+
+func collectUptime(o *Options) (interface{}, error) {
+	up, err := readUptime(o.path(procUptime))
+	if err != nil {
+		return nil, err
+	}
+	return uint64(up / time.Second), nil
+}
+
+func formatUptime(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		return []Line{{Name: label, Text: formatDuration(v.(uint64))}}, nil
+	}
+}
+
+// formatDuration formats secs as "N days, N hours, N minutes"
+func formatDuration(secs uint64) string {
+	days := secs / 86400
+	hours := secs % 86400 / 3600
+	minutes := secs % 3600 / 60
+
+	plural := func(n uint64, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return strconv.FormatUint(n, 10) + " " + unit + "s"
+	}
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, plural(days, "day"))
+	}
+	if days > 0 || hours > 0 {
+		parts = append(parts, plural(hours, "hour"))
+	}
+	parts = append(parts, plural(minutes, "minute"))
+
+	return strings.Join(parts, ", ")
+}
+
+func collectUpSince(o *Options) (interface{}, error) {
+	up, err := readUptime(o.path(procUptime))
+	if err != nil {
+		return nil, err
+	}
+	return time.Now().Add(-up).Truncate(time.Second), nil
+}
+
+func formatUpSince(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		since := strftime(v.(time.Time), o.UpSinceFormat)
+		return []Line{{Name: label, Text: since}}, nil
+	}
+}
+
+func collectWM(o *Options) (interface{}, error) {
+	return getWM(o.path(procDir), os.Getenv), nil
+}
+
+func collectDE(o *Options) (interface{}, error) {
+	return getDE(o.path("/"), os.Getenv), nil
+}
+
+// GTK setting selectors
+var (
+	gtkTheme  = func(g GTK) string { return g.Theme }
+	gtkIcons  = func(g GTK) string { return g.Icons }
+	gtkFont   = func(g GTK) string { return g.Font }
+	gtkCursor = func(g GTK) string { return g.Cursor }
+)
+
+// readGTK reads the user wide gtk config if it exists
+// otherwise the system wide one. If neither one of them
+// can be read all the settings are set to None.
+func readGTK(userRC, sysRC string) GTK {
+	none := GTK{
+		Theme:  "None",
+		Icons:  "None",
+		Font:   "None",
+		Cursor: "None",
+	}
+
+	var rc string
+	if utils.IsExistFile(userRC) {
+		rc = userRC
+	} else if utils.IsExistFile(sysRC) {
+		rc = sysRC
+	} else {
+		return none
+	}
+
+	gtk, err := GetGTKInfo(rc)
+	if err != nil {
+		return none
+	}
+	return gtk
+}
+
+func collectGTK2(setting func(GTK) string) CollectFunc {
+	return func(o *Options) (interface{}, error) {
+		return setting(readGTK(o.home(userGTK2rc), o.path(sysGTK2rc))), nil
+	}
+}
+
+func collectGTK3(setting func(GTK) string) CollectFunc {
+	return func(o *Options) (interface{}, error) {
+		return setting(readGTK(o.home(userGTK3rc), o.path(sysGTK3rc))), nil
+	}
+}
+
+func storeGTK2(set func(g *GTK, s string)) func(si *SystemInfo, v interface{}) {
+	return func(si *SystemInfo, v interface{}) {
+		if si.GTK2 == nil {
+			si.GTK2 = &GTK{}
+		}
+		set(si.GTK2, v.(string))
+	}
+}
+
+func storeGTK3(set func(g *GTK, s string)) func(si *SystemInfo, v interface{}) {
+	return func(si *SystemInfo, v interface{}) {
+		if si.GTK3 == nil {
+			si.GTK3 = &GTK{}
+		}
+		set(si.GTK3, v.(string))
+	}
+}
+
+// selfPid returns the pid of archey-go to walk up the process tree from.
+// Under a sysroot it's 0, which has no parents, as archey-go's pid
+// belongs to another pid namespace than the proc file system there.
+func selfPid(o *Options) int {
+	if o.Sysroot != "" {
+		return 0
+	}
+	return os.Getpid()
+}
+
+func collectTerminal(o *Options) (interface{}, error) {
+	return getTerminal(o.path(procDir), selfPid(o), os.Getenv), nil
+}
+
+func collectShell(o *Options) (interface{}, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the shell run for its version is killed once the timeout passed
+	return withTimeout("provider "+o.Sysroot+" shell", o.timeout("shell"), func() (interface{}, error) {
+		sh := getShell(o.path(procDir), o.path(etcPasswd), selfPid(o), os.Getuid(), os.Getenv)
+
+		// the environment and the binaries are the ones of the running
+		// system, so the version of the shell of another root isn't known
+		if o.Sysroot == "" {
+			sh.Version = envShellVersion(sh.Name, os.Getenv)
+			if sh.Version == "" && shellVersionFlag[sh.Name] {
+				sh.Version = runShellVersion(ctx, sh.Path)
+			}
+		}
+
+		return sh, nil
+	})
+}
+
+func formatShell(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		sh := v.(*Shell)
+
+		name, login := strings.Title(sh.Name), strings.Title(filepath.Base(sh.Login))
+		if o.ShellFull {
+			name, login = sh.Path, sh.Login
+		}
+		if sh.Version != "" {
+			name += " " + sh.Version
+		}
+		if sh.Login != "" && filepath.Base(sh.Login) != sh.Name {
+			name += " (login: " + login + ")"
+		}
+
+		return []Line{{Name: label, Text: name}}, nil
+	}
+}
+
+func collectEditor(o *Options) (interface{}, error) {
+	return strings.Title(os.Getenv("EDITOR")), nil
+}
+
+func collectPackages(o *Options) (interface{}, error) {
+	// without a home the per-user packages are left out, instead
+	// of looking for them at the root of the sysroot
+	return CountPackages(o.path("/"), o.home(""), splitList(o.NoPackageManagers)), nil
+}
+
+func collectMemory(o *Options) (interface{}, error) {
+	if o.Sysroot != "" {
+		mem, _, err := readMemInfo(o.path(procMeminfo))
+		return mem, err
+	}
+
+	mem := sysinfo.Mem{}
+	if err := mem.Get(); err != nil {
+		return nil, err
+	}
+	return &Usage{
+		Used:  uint64(mem.UsedMemInMB() * mib),
+		Total: uint64(mem.TotalMemInMB() * mib),
+	}, nil
+}
+
+func formatMemory(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		usage, err := formatUsage(v.(*Usage), o.MemoryUnit)
+		if err != nil {
+			return nil, ErrInvalidMemUnit(o.MemoryUnit)
+		}
+		return []Line{{Name: label, Text: usage}}, nil
+	}
+}
+
+func collectSwap(o *Options) (interface{}, error) {
+	if o.Sysroot != "" {
+		_, swap, err := readMemInfo(o.path(procMeminfo))
+		return swap, err
+	}
+
+	mem := sysinfo.Mem{}
+	if err := mem.Get(); err != nil {
+		return nil, err
+	}
+	return &Usage{
+		Used:  uint64(mem.UsedSwapInMB() * mib),
+		Total: uint64(mem.TotalSwapInMB() * mib),
+	}, nil
+}
+
+func formatSwap(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		usage, err := formatUsage(v.(*Usage), o.SwapUnit)
+		if err != nil {
+			return nil, ErrInvalidSwapUnit(o.SwapUnit)
+		}
+		return []Line{{Name: label, Text: usage}}, nil
+	}
+}
+
+func collectCPU(o *Options) (interface{}, error) {
+	if o.Sysroot != "" {
+		return readCPUName(o.path(procCPUInfo))
+	}
+
+	cpu := sysinfo.CPU{}
+	if err := cpu.Get(); err != nil {
+		return nil, err
+	}
+	return cpu.Name, nil
+}
+
+func collectGPU(o *Options) (interface{}, error) {
+	return getGPUs(o.path("/"))
+}
+
+func formatGPU(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		gpus := v.([]GPU)
+		if len(gpus) == 0 {
+			return []Line{{Name: label, Text: "None"}}, nil
+		}
+
+		var lines []Line
+		for _, g := range gpus {
+			var notes []string
+			if g.Driver != "" {
+				notes = append(notes, g.Driver)
+			}
+			// the primary one only stands out among several
+			if g.Primary && len(gpus) > 1 {
+				notes = append(notes, "primary")
+			}
+
+			text := g.name()
+			if len(notes) > 0 {
+				text += " (" + strings.Join(notes, ", ") + ")"
+			}
+			lines = append(lines, Line{Name: label, Text: text})
+		}
+		return lines, nil
+	}
+}
+
+func collectDisplays(o *Options) (interface{}, error) {
+	return getDisplays(o.path("/"))
+}
+
+func formatDisplays(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		displays := v.([]Display)
+		if len(displays) == 0 {
+			return []Line{{Name: label, Text: "None"}}, nil
+		}
+
+		var lines []Line
+		for _, d := range displays {
+			var parts []string
+			// monitor names often start with the manufacturer, e.g. DELL P2419H
+			model := d.Model
+			if !strings.HasPrefix(strings.ToLower(model), strings.ToLower(d.Manufacturer)) {
+				model = strings.TrimSpace(d.Manufacturer + " " + model)
+			}
+			if model != "" {
+				parts = append(parts, model)
+			}
+
+			res := d.Resolution
+			if d.Refresh > 0 {
+				res += fmt.Sprintf(" @ %.0f Hz", d.Refresh)
+			}
+			if res != "" {
+				parts = append(parts, res)
+			}
+			if d.Width > 0 && d.Height > 0 {
+				parts = append(parts, fmt.Sprintf("%.0f\"", d.diagonal()))
+			}
+
+			text := strings.Join(parts, ", ") + " (" + d.Connector + ")"
+			lines = append(lines, Line{Name: label, Text: strings.TrimSpace(text)})
+		}
+		return lines, nil
+	}
+}
+
+func collectBattery(o *Options) (interface{}, error) {
+	return getBatteries(o.path("/"))
+}
+
+// formatBattery prints nothing when there's no battery
+func formatBattery(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		batteries := v.([]Battery)
+
+		var lines []Line
+		for _, b := range batteries {
+			name := label
+			if len(batteries) > 1 {
+				name += " " + b.Name
+			}
+
+			details := []string{b.Status}
+			if b.Remaining > 0 {
+				details = append(details, formatDuration(b.Remaining)+" remaining")
+			}
+			if b.Health > 0 {
+				details = append(details, fmt.Sprintf("%.0f%% health", b.Health))
+			}
+
+			text := fmt.Sprintf("%d%% (%s)", b.Capacity, strings.Join(details, ", "))
+			lines = append(lines, Line{Name: name, Text: text})
+		}
+		return lines, nil
+	}
+}
+
+func collectNetwork(o *Options) (interface{}, error) {
+	// the addresses of another root's interfaces aren't known
+	addrs := interfaceAddrs
+	if o.Sysroot != "" {
+		addrs = func(string) []string { return nil }
+	}
+
+	network, err := getNetwork(o.path("/"), splitList(o.Interfaces), addrs)
+	if err != nil {
+		return nil, err
+	}
+	if o.MaskAddresses {
+		network = network.mask()
+	}
+	return network, nil
+}
+
+func formatNetwork(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		network := v.(*Network)
+		if len(network.Interfaces) == 0 {
+			return []Line{{Name: label, Text: "None"}}, nil
+		}
+
+		var lines []Line
+		for _, iface := range network.Interfaces {
+			text := fmt.Sprintf("%s (%s, %s)", iface.Name, iface.Type, iface.State)
+			if len(iface.Addresses) > 0 {
+				text += " " + strings.Join(iface.Addresses, ", ")
+			}
+			lines = append(lines, Line{Name: label, Text: text})
+		}
+		if network.Gateway != "" {
+			lines = append(lines, Line{Name: "Gateway",
+				Text: network.Gateway + " (" + network.GatewayInterface + ")"})
+		}
+		return lines, nil
+	}
+}
+
+// fsUsage returns the disk usage of the file system path is on
+func fsUsage(path string) (*Usage, error) {
+	fs := sysinfo.FS{}
+	if err := fs.Get(path); err != nil {
+		return nil, err
+	}
+	return &Usage{
+		Used:  uint64(fs.UsedSpaceInMB() * mib),
+		Total: uint64(fs.TotalSizeInMB() * mib),
+	}, nil
+}
+
+func collectFS(path string) CollectFunc {
+	return func(o *Options) (interface{}, error) {
+		return fsUsage(o.path(path))
+	}
+}
+
+// formatFS uses the label of the provider or full if full paths are requested
+func formatFS(full string) func(label string) FormatFunc {
+	return func(label string) FormatFunc {
+		return func(o *Options, v interface{}) ([]Line, error) {
+			usage, err := formatUsage(v.(*Usage), o.DiskUnit)
+			if err != nil {
+				return nil, ErrInvalidDiskUnit(o.DiskUnit)
+			}
+
+			name := label
+			if o.PathFull {
+				name = full
+			}
+			return []Line{{Name: name, Text: usage}}, nil
+		}
+	}
+}
+
+// collectPaths reads the usage of every path concurrently, each
+// within the paths timeout so a hung mount doesn't hide the others
+func collectPaths(o *Options) (interface{}, error) {
+	sl := splitList(o.Paths)
+	paths := make([]PathUsage, len(sl))
+	errs := make([]error, len(sl))
+
+	var wg sync.WaitGroup
+	for i, path := range sl {
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			paths[i].Path = path
+
+			usage, err := withTimeout("path "+o.path(path), o.timeout("paths"), func() (interface{}, error) {
+				return fsUsage(o.path(path))
+			})
+			switch err {
+			case nil:
+				paths[i].Usage = *usage.(*Usage)
+			case ErrTimeout:
+				paths[i].TimedOut = true
+			default:
+				errs[i] = err
+			}
+		}(i, path)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+func formatPaths(o *Options, v interface{}) ([]Line, error) {
+	var lines []Line
+	for _, p := range v.([]PathUsage) {
+		usage, err := formatUsage(&p.Usage, o.DiskUnit)
+		if err != nil {
+			return nil, ErrInvalidDiskUnit(o.DiskUnit)
+		}
+		if p.TimedOut {
+			usage = timeoutText
+		}
+
+		path := p.Path
+		if !o.PathFull {
+			path = strings.Title(strings.ToLower(filepath.Base(path)))
+		}
+		lines = append(lines, Line{Name: path, Text: usage})
+	}
+	return lines, nil
+}
+
+// formatUsage formats u as "used / total" in MB or GB
+func formatUsage(u *Usage, unit string) (string, error) {
+	switch strings.ToLower(unit) {
+	case "mb":
+		return fmt.Sprintf("%.1f MB / %.1f MB",
+			float64(u.Used)/mib, float64(u.Total)/mib), nil
+	case "gb":
+		return fmt.Sprintf("%.1f GB / %.1f GB",
+			float64(u.Used)/mib/1024, float64(u.Total)/mib/1024), nil
+	default:
+		return "", fmt.Errorf("invalid unit '%s'", unit)
+	}
+}
+
+func collectCustom(o *Options) (interface{}, error) {
+	for _, c := range o.Custom {
+		if err := c.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	values := make([]CustomValue, len(o.Custom))
+	var wg sync.WaitGroup
+	for i, c := range o.Custom {
+		wg.Add(1)
+		go func(i int, c Custom) {
+			defer wg.Done()
+			values[i].Label = c.Label
+
+			text, err := c.collect(o.timeout("custom"))
+			switch err {
+			case nil:
+				values[i].Text = text
+			case ErrTimeout:
+				values[i].TimedOut = true
+			default:
+				values[i].Error = err.Error()
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	return values, nil
+}
+
+func formatCustom(o *Options, v interface{}) ([]Line, error) {
+	var lines []Line
+	for _, c := range v.([]CustomValue) {
+		text := c.Text
+		switch {
+		case c.TimedOut:
+			text = timeoutText
+		case c.Error != "":
+			text = "error: " + c.Error
+		}
+		lines = append(lines, Line{Name: c.Label, Text: text})
+	}
+	return lines, nil
+}
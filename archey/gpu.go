@@ -0,0 +1,201 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const sysDRM = "/sys/class/drm"
+
+// locations of the PCI ID database, in order of preference
+var pciIDsFiles = []string{
+	"/usr/share/hwdata/pci.ids",
+	"/usr/share/misc/pci.ids",
+}
+
+// shorter names of the most common GPU vendors
+var gpuVendors = map[string]string{
+	"10de": "NVIDIA",
+	"1002": "AMD",
+	"8086": "Intel",
+	"1af4": "Virtio",
+	"15ad": "VMware",
+	"1234": "QEMU",
+	"80ee": "VirtualBox",
+	"1414": "Microsoft",
+	"1a03": "ASPEED",
+	"102b": "Matrox",
+}
+
+// DRM cards, leaving out their connectors such as card0-HDMI-A-1
+var drmCard = regexp.MustCompile(`^card\d+$`)
+
+// GPU is a graphics card found in /sys/class/drm
+type GPU struct {
+	VendorID string `json:"vendor_id"`
+	DeviceID string `json:"device_id"`
+	Vendor   string `json:"vendor"`
+	Device   string `json:"device"`
+	Driver   string `json:"driver"`
+	// Primary is set for the GPU the firmware booted on
+	Primary bool `json:"primary"`
+}
+
+// getGPUs returns the GPUs of the system mounted at root,
+// naming them through the first PCI ID database found
+func getGPUs(root string) ([]GPU, error) {
+	cards, err := filepath.Glob(filepath.Join(root, sysDRM, "card*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cardNumber(cards[i]) < cardNumber(cards[j])
+	})
+
+	gpus := []GPU{}
+	for _, card := range cards {
+		if !drmCard.MatchString(filepath.Base(card)) {
+			continue
+		}
+
+		dev := filepath.Join(card, "device")
+		vendor, err := readFirstLine(filepath.Join(dev, "vendor"))
+		if err != nil {
+			continue
+		}
+		device, _ := readFirstLine(filepath.Join(dev, "device"))
+		bootVGA, _ := readFirstLine(filepath.Join(dev, "boot_vga"))
+		driver, _ := os.Readlink(filepath.Join(dev, "driver"))
+		if driver != "" {
+			driver = filepath.Base(driver)
+		}
+
+		gpus = append(gpus, GPU{
+			VendorID: strings.TrimPrefix(strings.ToLower(vendor), "0x"),
+			DeviceID: strings.TrimPrefix(strings.ToLower(device), "0x"),
+			Driver:   driver,
+			Primary:  bootVGA == "1",
+		})
+	}
+
+	var ids string
+	for _, f := range pciIDsFiles {
+		if _, err := os.Stat(filepath.Join(root, f)); err == nil {
+			ids = filepath.Join(root, f)
+			break
+		}
+	}
+	for i := range gpus {
+		gpus[i].Vendor, gpus[i].Device = pciName(ids, gpus[i].VendorID, gpus[i].DeviceID)
+	}
+
+	return gpus, nil
+}
+
+// cardNumber returns the number of a DRM card, so card10 comes after card9
+func cardNumber(card string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(card), "card"))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// pciName looks up the names of a vendor and its device in the PCI ID
+// database ids. Names which can't be found are left empty.
+func pciName(ids, vendor, device string) (string, string) {
+	f, err := os.Open(ids)
+	if err != nil {
+		return "", ""
+	}
+	defer f.Close()
+
+	var vendorName string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		// vendors aren't indented, their devices are indented by one tab
+		// and subsystems by two
+		if line[0] != '\t' {
+			if vendorName != "" {
+				break
+			}
+			if id, name := pciEntry(line); id == vendor {
+				vendorName = name
+			}
+		} else if vendorName != "" && !strings.HasPrefix(line, "\t\t") {
+			if id, name := pciEntry(line[1:]); id == device {
+				return vendorName, name
+			}
+		}
+	}
+
+	return vendorName, ""
+}
+
+// pciEntry splits a line of the PCI ID database into its id and name
+func pciEntry(line string) (string, string) {
+	fields := strings.SplitN(line, "  ", 2)
+	if len(fields) != 2 {
+		return "", ""
+	}
+	return strings.ToLower(fields[0]), strings.TrimSpace(fields[1])
+}
+
+// name returns the short name of the GPU, e.g. "NVIDIA GeForce RTX 3070"
+func (g GPU) name() string {
+	vendor, ok := gpuVendors[g.VendorID]
+	if !ok {
+		vendor = g.Vendor
+	}
+	if vendor == "" {
+		vendor = g.VendorID
+	}
+
+	// the marketing name is between brackets, e.g. "GA104 [GeForce RTX 3070]"
+	device := g.Device
+	if i, j := strings.Index(device, "["), strings.LastIndex(device, "]"); i >= 0 && j > i {
+		device = device[i+1 : j]
+	}
+	if device == "" {
+		device = g.DeviceID
+	}
+
+	return vendor + " " + device
+}
+
+// GetGPUs returns the GPUs of the system
+func GetGPUs() ([]GPU, error) {
+	return getGPUs("/")
+}
@@ -0,0 +1,100 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGetGPUs(t *testing.T) {
+	tests := []struct {
+		root  string
+		want  []GPU
+		names []string
+	}{
+		{sysroot,
+			[]GPU{
+				{"8086", "46a6", "Intel Corporation", "Alder Lake-P GT2 [Iris Xe Graphics]", "i915", true},
+				{"10de", "2484", "NVIDIA Corporation", "GA104 [GeForce RTX 3070]", "nvidia", false},
+			},
+			[]string{"Intel Iris Xe Graphics", "NVIDIA GeForce RTX 3070"}},
+		// card10 comes after card2, cards without a device are left out
+		// and the database is found in its second location
+		{"testdata/gpu",
+			[]GPU{
+				{"1002", "73bf", "Advanced Micro Devices, Inc. [AMD/ATI]",
+					"Navi 21 [Radeon RX 6800/6800 XT / 6900 XT]", "", false},
+				{"1b36", "0100", "Red Hat, Inc.", "QXL paravirtual graphic card", "", false},
+			},
+			[]string{"AMD Radeon RX 6800/6800 XT / 6900 XT", "Red Hat, Inc. QXL paravirtual graphic card"}},
+		{"testdata/missing", []GPU{}, nil},
+	}
+
+	for _, tt := range tests {
+		got, err := getGPUs(tt.root)
+		if err != nil {
+			t.Errorf("%s: %v", tt.root, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.root, got, tt.want)
+			continue
+		}
+		for i, g := range got {
+			if name := g.name(); name != tt.names[i] {
+				t.Errorf("%s: got name %q, want %q", tt.root, name, tt.names[i])
+			}
+		}
+	}
+}
+
+func TestPCIName(t *testing.T) {
+	ids := filepath.Join(sysroot, pciIDsFiles[0])
+
+	tests := []struct {
+		ids, vendor, device string
+		wantVendor, want    string
+	}{
+		{ids, "8086", "46a6", "Intel Corporation", "Alder Lake-P GT2 [Iris Xe Graphics]"},
+		{ids, "10de", "2484", "NVIDIA Corporation", "GA104 [GeForce RTX 3070]"},
+		// subsystems and the devices of other vendors aren't matched
+		{ids, "8086", "1028", "Intel Corporation", ""},
+		{ids, "1002", "2484", "Advanced Micro Devices, Inc. [AMD/ATI]", ""},
+		{ids, "ffff", "0000", "", ""},
+		{"testdata/missing", "8086", "46a6", "", ""},
+	}
+
+	for _, tt := range tests {
+		vendor, device := pciName(tt.ids, tt.vendor, tt.device)
+		if vendor != tt.wantVendor || device != tt.want {
+			t.Errorf("%s %s:%s: got %q, %q, want %q, %q",
+				tt.ids, tt.vendor, tt.device, vendor, device, tt.wantVendor, tt.want)
+		}
+	}
+
+	if name := (GPU{VendorID: "abcd", DeviceID: "0001"}).name(); name != "abcd 0001" {
+		t.Errorf("got name %q of an unknown GPU, want the ids", name)
+	}
+}
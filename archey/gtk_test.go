@@ -0,0 +1,52 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import "testing"
+
+func TestCollectGTKHome(t *testing.T) {
+	o := New()
+	o.Sysroot = "testdata/gtk"
+
+	tests := []struct {
+		home string
+		want string
+	}{
+		{"/home/user", "Arc-Dark"},
+		// HOME is read when collecting, not when the package is loaded
+		{"/home/other", "Adwaita"},
+		// without a home only the system wide file is read
+		{"", "Adwaita"},
+	}
+
+	for _, tt := range tests {
+		setenv(t, map[string]string{"HOME": tt.home})
+		got, err := collectGTK2(gtkTheme)(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("HOME=%q: got %v, want %q", tt.home, got, tt.want)
+		}
+	}
+}
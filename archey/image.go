@@ -0,0 +1,420 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // decode GIF images
+	_ "image/jpeg" // decode JPEG images
+	"image/png"
+	"os"
+	"strings"
+
+	"github.com/mgutz/ansi"
+)
+
+// ways to display an image
+const (
+	imageAuto   = "auto"
+	imageKitty  = "kitty"
+	imageSixel  = "sixel"
+	imageITerm  = "iterm"
+	imageBlocks = "blocks"
+)
+
+// assumed size of a terminal cell in pixels, as the
+// real one can't be known without asking the terminal
+const (
+	cellWidth  = 8
+	cellHeight = 16
+)
+
+// default width of the image in columns
+const defImageWidth = 32
+
+// size of the base64 chunks of the kitty graphics protocol
+const kittyChunk = 4096
+
+var ErrInvalidImageProtocol = func(p string) error {
+	return fmt.Errorf("invalid image protocol '%s'", p)
+}
+
+// detectImageProtocol picks the way to display images
+// the terminal supports based on its environment
+func detectImageProtocol(getenv func(string) string) string {
+	term, program := getenv("TERM"), getenv("TERM_PROGRAM")
+
+	// tmux doesn't pass the graphics through by default
+	if getenv("TMUX") != "" || strings.HasPrefix(term, "screen") {
+		return imageBlocks
+	}
+
+	switch {
+	case term == "xterm-kitty" || getenv("KITTY_WINDOW_ID") != "" ||
+		term == "xterm-ghostty":
+		return imageKitty
+	case program == "iTerm.app" || program == "WezTerm" ||
+		getenv("LC_TERMINAL") == "iTerm2":
+		return imageITerm
+	case strings.Contains(term, "sixel") || strings.HasPrefix(term, "foot") ||
+		strings.HasPrefix(term, "mlterm") || strings.HasPrefix(term, "contour"):
+		return imageSixel
+	}
+	return imageBlocks
+}
+
+// loadImage decodes the image file f
+func loadImage(f string) (image.Image, error) {
+	file, err := os.Open(f)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", f, err)
+	}
+	return img, nil
+}
+
+// imageRows returns how many rows img takes up when it's cols wide
+func imageRows(img image.Image, cols int) int {
+	b := img.Bounds()
+	if b.Dx() == 0 {
+		return 1
+	}
+
+	rows := (cols*cellWidth*b.Dy()/b.Dx() + cellHeight/2) / cellHeight
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// scaleImage resizes src to w by h pixels, averaging
+// the source pixels each pixel covers
+func scaleImage(src image.Image, w, h int) *image.NRGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	span := func(i, n, sn int) (int, int) {
+		from, to := i*sn/n, (i+1)*sn/n
+		if to <= from {
+			to = from + 1
+		}
+		return from, to
+	}
+
+	for y := 0; y < h; y++ {
+		sy0, sy1 := span(y, h, sh)
+		for x := 0; x < w; x++ {
+			sx0, sx1 := span(x, w, sw)
+
+			// the colors are weighted by their alpha
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					c := color.NRGBAModel.Convert(src.At(b.Min.X+sx, b.Min.Y+sy)).(color.NRGBA)
+					r += uint64(c.R) * uint64(c.A)
+					g += uint64(c.G) * uint64(c.A)
+					bl += uint64(c.B) * uint64(c.A)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			if a > 0 {
+				dst.SetNRGBA(x, y, color.NRGBA{
+					R: uint8(r / a), G: uint8(g / a), B: uint8(bl / a), A: uint8(a / n),
+				})
+			}
+		}
+	}
+
+	return dst
+}
+
+// encodeKitty returns the kitty graphics protocol sequence displaying
+// img over cols by rows cells without moving the cursor
+func encodeKitty(img image.Image, cols, rows int) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var sb strings.Builder
+	for i := 0; i < len(data); i += kittyChunk {
+		end := i + kittyChunk
+		more := 1
+		if end >= len(data) {
+			end, more = len(data), 0
+		}
+
+		if i == 0 {
+			fmt.Fprintf(&sb, "\033_Ga=T,f=100,c=%d,r=%d,C=1,q=2,m=%d;", cols, rows, more)
+		} else {
+			fmt.Fprintf(&sb, "\033_Gm=%d;", more)
+		}
+		sb.WriteString(data[i:end])
+		sb.WriteString("\033\\")
+	}
+
+	return sb.String(), nil
+}
+
+// encodeITerm returns the iTerm2 inline image sequence
+// displaying img over cols by rows cells
+func encodeITerm(img image.Image, cols, rows int) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("\033]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0:%s\a",
+		buf.Len(), cols, rows, base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
+
+// encodeSixel returns the sixel sequence of img. The colors are reduced
+// to the 6x6x6 color cube and transparent pixels are left untouched.
+func encodeSixel(img *image.NRGBA) string {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// palette index of every pixel, -1 for transparent ones
+	level := func(v uint8) int { return (int(v)*5 + 127) / 255 }
+	pixels := make([]int, w*h)
+	var used [216]bool
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := img.NRGBAAt(b.Min.X+x, b.Min.Y+y)
+			i := -1
+			if c.A >= 128 {
+				i = 36*level(c.R) + 6*level(c.G) + level(c.B)
+				used[i] = true
+			}
+			pixels[y*w+x] = i
+		}
+	}
+
+	var sb strings.Builder
+	// transparent background, 1:1 pixel aspect ratio
+	fmt.Fprintf(&sb, "\033P0;1;0q\"1;1;%d;%d", w, h)
+	for i, u := range used {
+		if u {
+			fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+		}
+	}
+
+	// bands of six rows, drawn one color at a time
+	for y0 := 0; y0 < h; y0 += 6 {
+		var colors []int
+		var inBand [216]bool
+		for y := y0; y < y0+6 && y < h; y++ {
+			for x := 0; x < w; x++ {
+				if i := pixels[y*w+x]; i >= 0 {
+					inBand[i] = true
+				}
+			}
+		}
+		for i, in := range inBand {
+			if in {
+				colors = append(colors, i)
+			}
+		}
+
+		for n, c := range colors {
+			if n > 0 {
+				sb.WriteByte('$')
+			}
+			fmt.Fprintf(&sb, "#%d", c)
+
+			row := make([]byte, w)
+			for x := 0; x < w; x++ {
+				var bits byte
+				for k := 0; k < 6 && y0+k < h; k++ {
+					if pixels[(y0+k)*w+x] == c {
+						bits |= 1 << uint(k)
+					}
+				}
+				row[x] = 63 + bits
+			}
+			writeSixelRun(&sb, row)
+		}
+		sb.WriteByte('-')
+	}
+
+	sb.WriteString("\033\\")
+	return sb.String()
+}
+
+// writeSixelRun writes the sixels of row with runs of the same sixel compressed
+func writeSixelRun(sb *strings.Builder, row []byte) {
+	for i := 0; i < len(row); {
+		j := i
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if n := j - i; n > 3 {
+			fmt.Fprintf(sb, "!%d%c", n, row[i])
+		} else {
+			sb.Write(row[i:j])
+		}
+		i = j
+	}
+}
+
+// halfBlocks draws img with the upper and lower half block characters,
+// two pixels to a cell. img must be twice as high as the rows drawn.
+func halfBlocks(img *image.NRGBA, depth int) ([]string, error) {
+	b := img.Bounds()
+	reset := ansi.ColorCode(resetColor)
+
+	hex := func(c color.NRGBA) string {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+
+	var lines []string
+	for y := 0; y < b.Dy(); y += 2 {
+		var sb strings.Builder
+		prev := ""
+		for x := 0; x < b.Dx(); x++ {
+			top := img.NRGBAAt(b.Min.X+x, b.Min.Y+y)
+			var bottom color.NRGBA
+			if y+1 < b.Dy() {
+				bottom = img.NRGBAAt(b.Min.X+x, b.Min.Y+y+1)
+			}
+
+			block, style := " ", ""
+			switch {
+			case top.A >= 128 && bottom.A >= 128:
+				block, style = "▀", hex(top)+":"+hex(bottom)
+			case top.A >= 128:
+				block, style = "▀", hex(top)
+			case bottom.A >= 128:
+				block, style = "▄", hex(bottom)
+			}
+
+			code := reset
+			if style != "" {
+				var err error
+				if code, err = colorCode(style, depth); err != nil {
+					return nil, err
+				}
+			}
+			if code != prev {
+				sb.WriteString(code)
+				prev = code
+			}
+			sb.WriteString(block)
+		}
+		lines = append(lines, sb.String())
+	}
+
+	return lines, nil
+}
+
+// imageProtocol returns how Image is displayed,
+// detecting it from the terminal for auto
+func (o *Options) imageProtocol() string {
+	protocol := strings.ToLower(o.ImageProtocol)
+	if protocol == "" || protocol == imageAuto {
+		protocol = detectImageProtocol(os.Getenv)
+	}
+	return protocol
+}
+
+// renderImage returns Image with the info lines to its right
+func (o *Options) renderImage(info []string) (string, error) {
+	img, err := loadImage(o.Image)
+	if err != nil {
+		return "", err
+	}
+
+	cols := o.ImageWidth
+	if cols <= 0 {
+		cols = defImageWidth
+	}
+	rows := imageRows(img, cols)
+
+	var seq string
+	switch o.imageProtocol() {
+	case imageBlocks:
+		art, err := halfBlocks(scaleImage(img, cols, rows*2), termColorDepth())
+		if err != nil {
+			return "", err
+		}
+
+		widths := make([]int, len(art))
+		for i := range widths {
+			widths[i] = cols
+		}
+		return joinColumns(art, widths, cols, info), nil
+	case imageKitty:
+		seq, err = encodeKitty(scaleImage(img, cols*cellWidth, rows*cellHeight), cols, rows)
+	case imageITerm:
+		seq, err = encodeITerm(scaleImage(img, cols*cellWidth, rows*cellHeight), cols, rows)
+	case imageSixel:
+		seq = encodeSixel(scaleImage(img, cols*cellWidth, rows*cellHeight))
+	default:
+		return "", ErrInvalidImageProtocol(o.ImageProtocol)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return placeImage(seq, cols, rows, info), nil
+}
+
+// placeImage draws the image sequence seq over cols by rows cells
+// and places the info lines to its right with cursor movements
+func placeImage(seq string, cols, rows int, info []string) string {
+	var sb strings.Builder
+
+	// start with an empty line
+	sb.WriteString("\n")
+
+	// make room for the image first so drawing it doesn't scroll,
+	// then draw it from its top left corner and come back there
+	sb.WriteString(strings.Repeat("\n", rows))
+	fmt.Fprintf(&sb, "\033[%dA\0337%s\0338", rows, seq)
+
+	lines := rows
+	if len(info) > lines {
+		lines = len(info)
+	}
+	for i := 0; i < lines; i++ {
+		if i < len(info) {
+			fmt.Fprintf(&sb, "\033[%dG%s", cols+logoGap+1, info[i])
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mgutz/ansi"
+)
+
+// a 4x3 image with a transparent and a translucent pixel
+const tinyImage = "testdata/image/tiny.png"
+
+func TestRenderImage(t *testing.T) {
+	tests := []struct {
+		protocol  string
+		colorterm string
+		golden    string
+	}{
+		{imageKitty, "truecolor", "kitty"},
+		{imageITerm, "truecolor", "iterm"},
+		{imageSixel, "truecolor", "sixel"},
+		{imageBlocks, "truecolor", "blocks"},
+		{imageBlocks, "", "blocks-256"},
+	}
+
+	info := []string{"OS: Arch Linux", "Kernel: 6.9.1-arch1-1", "Uptime: 1 day"}
+	for _, tt := range tests {
+		setenv(t, map[string]string{"TERM": "xterm-256color", "COLORTERM": tt.colorterm})
+
+		o := New()
+		o.Image = tinyImage
+		o.ImageProtocol = tt.protocol
+		o.ImageWidth = 4
+
+		out, err := o.renderImage(info)
+		if err != nil {
+			t.Errorf("%s: %v", tt.protocol, err)
+			continue
+		}
+		golden(t, "testdata/image/"+tt.golden+".golden", out)
+	}
+}
+
+func TestRenderImageNoColor(t *testing.T) {
+	setenv(t, map[string]string{"TERM": "xterm-256color", "COLORTERM": ""})
+
+	plain = true
+	ansi.DisableColors(true)
+	defer func() {
+		plain = false
+		ansi.DisableColors(false)
+	}()
+
+	o := New()
+	o.Logo = "arch"
+	logo, err := o.RenderInfo(SystemInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// half blocks need colors, the other protocols draw the image as is
+	o.Image = tinyImage
+	o.ImageProtocol = imageBlocks
+	if out, err := o.RenderInfo(SystemInfo{}); err != nil || out != logo {
+		t.Errorf("got %q (%v) for half blocks without colors, want the logo", out, err)
+	}
+
+	o.ImageProtocol = imageKitty
+	if out, err := o.RenderInfo(SystemInfo{}); err != nil || !strings.Contains(out, "\033_G") {
+		t.Errorf("got %q (%v) for kitty without colors, want the image", out, err)
+	}
+}
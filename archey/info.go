@@ -0,0 +1,399 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SystemInfo holds the raw values of the enabled fields.
+// Fields which are hidden are left to their zero value.
+type SystemInfo struct {
+	OS        string
+	Arch      string
+	Kernel    string
+	User      string
+	Hostname  string
+	Uptime    uint64 // seconds
+	UpSince   time.Time
+	WM        string
+	DE        string
+	GTK2      *GTK
+	GTK3      *GTK
+	Terminal  string
+	Shell     *Shell
+	Editor    string
+	Packages  []PackageCount
+	Memory    *Usage
+	Swap      *Usage
+	CPU       string
+	GPUs      []GPU
+	Displays  []Display
+	Batteries []Battery
+	Network   *Network
+	Root      *Usage
+	Home      *Usage
+	Paths     []PathUsage
+	Custom    []CustomValue
+	// Extra holds the values collected by providers
+	// registered outside of archey, keyed by provider name
+	Extra map[string]interface{}
+	// TimedOut holds the names of the providers
+	// which didn't finish collecting within their timeout
+	TimedOut []string
+
+	// values collected by every provider, keyed by provider name
+	values map[string]interface{}
+}
+
+// Usage of memory, swap or disk space in bytes
+type Usage struct {
+	Used  uint64 `json:"used"`
+	Total uint64 `json:"total"`
+}
+
+// PathUsage is the disk usage of the file system path is on
+type PathUsage struct {
+	Path string `json:"path"`
+	Usage
+	// TimedOut is set if the usage couldn't be read within the timeout
+	TimedOut bool `json:"timed_out,omitempty"`
+}
+
+// ErrTimeout is returned when collecting a value takes longer than its timeout
+var ErrTimeout = errors.New("timeout")
+
+// text displayed instead of the values which timed out
+const timeoutText = "timeout"
+
+// Collect gathers the raw values of all the providers which are
+// displayed according to the options. The providers are run concurrently and the
+// ones which exceed their timeout are added to TimedOut.
+func Collect(o *Options) (SystemInfo, error) {
+	si := SystemInfo{values: make(map[string]interface{})}
+
+	if _, err := o.modules(); err != nil {
+		return si, err
+	}
+
+	err := si.collect(o, func(Provider) bool { return true })
+	return si, err
+}
+
+// Refresh returns a copy of si with the values of the dynamic providers,
+// such as memory usage and uptime, collected again. The values of the
+// other providers are kept as they are.
+func Refresh(o *Options, si SystemInfo) (SystemInfo, error) {
+	next := si
+	next.values = make(map[string]interface{}, len(si.values))
+	for name, v := range si.values {
+		next.values[name] = v
+	}
+	if si.Extra != nil {
+		next.Extra = make(map[string]interface{}, len(si.Extra))
+		for name, v := range si.Extra {
+			next.Extra[name] = v
+		}
+	}
+
+	next.TimedOut = nil
+	for _, name := range si.TimedOut {
+		if p, ok := Lookup(name); !ok || !isDynamic(p) {
+			next.TimedOut = append(next.TimedOut, name)
+		}
+	}
+
+	err := next.collect(o, isDynamic)
+	return next, err
+}
+
+// collect gathers the values of the shown providers for which filter is true
+func (si *SystemInfo) collect(o *Options, filter func(Provider) bool) error {
+	var providers []Provider
+	for _, p := range Providers() {
+		if o.shown(p.Name()) && filter(p) {
+			providers = append(providers, p)
+		}
+	}
+
+	type result struct {
+		v   interface{}
+		err error
+	}
+
+	results := make([]result, len(providers))
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func(i int, p Provider) {
+			defer wg.Done()
+
+			timeout := o.timeout(p.Name())
+			if bp, ok := p.(*provider); ok && bp.selfTimed {
+				timeout = 0
+			}
+
+			// the same provider reads other files under another root
+			key := "provider " + o.Sysroot + " " + p.Name()
+			v, err := withTimeout(key, timeout, func() (interface{}, error) {
+				return p.Collect(o)
+			})
+			results[i] = result{v, err}
+		}(i, p)
+	}
+	wg.Wait()
+
+	// keep the registration order and report the first error
+	for i, p := range providers {
+		switch err := results[i].err; err {
+		case nil:
+			si.set(p, results[i].v)
+		case ErrTimeout:
+			si.TimedOut = append(si.TimedOut, p.Name())
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// calls of withTimeout which timed out and haven't returned yet, keyed by
+// what they collect. While one is blocked, e.g. in statfs on a hung NFS
+// mount, the same value isn't collected again, so collecting every few
+// seconds doesn't pile up goroutines and the OS threads blocked under them.
+var (
+	blockedMu sync.Mutex
+	blocked   = make(map[string]*blockedCall)
+)
+
+type blockedCall struct {
+	done bool
+}
+
+// withTimeout calls fn and waits at most d for it to return. If d is zero
+// or negative it waits for as long as it takes. While a previous call with
+// the same key is still running past its timeout, fn isn't called and
+// ErrTimeout is returned right away.
+func withTimeout(key string, d time.Duration, fn func() (interface{}, error)) (interface{}, error) {
+	if d <= 0 {
+		return fn()
+	}
+
+	blockedMu.Lock()
+	if _, ok := blocked[key]; ok {
+		blockedMu.Unlock()
+		return nil, ErrTimeout
+	}
+	blockedMu.Unlock()
+
+	type result struct {
+		v   interface{}
+		err error
+	}
+
+	// buffered so fn can finish after timing out
+	ch := make(chan result, 1)
+	call := &blockedCall{}
+	go func() {
+		v, err := fn()
+
+		blockedMu.Lock()
+		call.done = true
+		if blocked[key] == call {
+			delete(blocked, key)
+		}
+		blockedMu.Unlock()
+
+		ch <- result{v, err}
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case r := <-ch:
+		return r.v, r.err
+	case <-timer.C:
+		blockedMu.Lock()
+		if !call.done {
+			blocked[key] = call
+		}
+		blockedMu.Unlock()
+		return nil, ErrTimeout
+	}
+}
+
+// timedOut reports whether the named provider timed out
+func (si SystemInfo) timedOut(name string) bool {
+	for _, n := range si.TimedOut {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns the value collected by the provider registered under name
+func (si SystemInfo) Value(name string) (interface{}, bool) {
+	v, ok := si.values[name]
+	return v, ok
+}
+
+func (si *SystemInfo) set(p Provider, v interface{}) {
+	if si.values == nil {
+		si.values = make(map[string]interface{})
+	}
+	si.values[p.Name()] = v
+
+	if bp, ok := p.(*provider); ok && bp.store != nil {
+		bp.store(si, v)
+		return
+	}
+
+	if si.Extra == nil {
+		si.Extra = make(map[string]interface{})
+	}
+	si.Extra[p.Name()] = v
+}
+
+// has reports whether any of the named providers was collected
+func (si SystemInfo) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := si.values[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+// jsonFields returns the collected fields in the order they're encoded,
+// leaving out the fields which are hidden. Values of providers
+// registered outside of archey are added under their name.
+func (si SystemInfo) jsonFields() []jsonField {
+	var fields []jsonField
+	add := func(key string, value interface{}, names ...string) {
+		if si.has(names...) {
+			fields = append(fields, jsonField{key, value})
+		}
+	}
+
+	add("os", si.OS, "os")
+	add("arch", si.Arch, "os")
+	add("kernel", si.Kernel, "kernel")
+	add("user", si.User, "user")
+	add("hostname", si.Hostname, "hostname")
+	add("uptime", si.Uptime, "uptime")
+	add("up_since", si.UpSince, "up_since")
+	add("wm", si.WM, "wm")
+	add("de", si.DE, "de")
+	add("gtk2", si.GTK2, "gtk2_theme", "gtk2_icon_theme",
+		"gtk2_font", "gtk2_cursor_theme")
+	add("gtk3", si.GTK3, "gtk3_theme", "gtk3_icon_theme",
+		"gtk3_font", "gtk3_cursor_theme")
+	add("terminal", si.Terminal, "terminal")
+	add("shell", si.Shell, "shell")
+	add("editor", si.Editor, "editor")
+	add("packages", si.Packages, "packages")
+	add("memory", si.Memory, "memory")
+	add("swap", si.Swap, "swap")
+	add("cpu", si.CPU, "cpu")
+	add("gpu", si.GPUs, "gpu")
+	add("displays", si.Displays, "displays")
+	add("battery", si.Batteries, "battery")
+	add("network", si.Network, "network")
+	add("root", si.Root, "root")
+	add("home", si.Home, "home")
+	if si.has("paths") {
+		// always an array, even when there are no additional paths
+		paths := si.Paths
+		if paths == nil {
+			paths = []PathUsage{}
+		}
+		fields = append(fields, jsonField{"paths", paths})
+	}
+	add("custom", si.Custom, "custom")
+
+	var extra []string
+	for name := range si.Extra {
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		fields = append(fields, jsonField{name, si.Extra[name]})
+	}
+
+	if len(si.TimedOut) > 0 {
+		fields = append(fields, jsonField{"timed_out", si.TimedOut})
+	}
+
+	return fields
+}
+
+// Field returns the value encoded under key in the JSON
+// object of si, e.g. "memory", "arch" or "timed_out"
+func (si SystemInfo) Field(key string) (interface{}, bool) {
+	for _, f := range si.jsonFields() {
+		if f.key == key {
+			return f.value, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON encodes the collected fields as a JSON object
+func (si SystemInfo) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range si.jsonFields() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,146 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithTimeoutBlocked(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	hang := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "done", nil
+	}
+
+	const key = "test hang"
+	if _, err := withTimeout(key, 10*time.Millisecond, hang); err != ErrTimeout {
+		t.Fatalf("got %v, want %v", err, ErrTimeout)
+	}
+
+	// the first call is still blocked, so it isn't made again
+	for i := 0; i < 10; i++ {
+		if _, err := withTimeout(key, 10*time.Millisecond, hang); err != ErrTimeout {
+			t.Fatalf("got %v, want %v", err, ErrTimeout)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("got %d calls while the first one is blocked, want 1", n)
+	}
+
+	// other keys are collected as usual
+	v, err := withTimeout("test other", time.Second, func() (interface{}, error) { return 1, nil })
+	if err != nil || v != 1 {
+		t.Errorf("got %v, %v for another key, want 1", v, err)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		blockedMu.Lock()
+		_, ok := blocked[key]
+		blockedMu.Unlock()
+		if !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the call is still marked as blocked after returning")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	v, err = withTimeout(key, time.Second, hang)
+	if err != nil || v != "done" {
+		t.Errorf("got %v, %v once the call returned, want done", v, err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("got %d calls, want 2", n)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	si := infoOf(t, map[string]interface{}{
+		"memory": &Usage{Used: 1, Total: 2},
+		"os":     osInfo{"Arch Linux", "x86_64"},
+		"paths":  []PathUsage(nil),
+		"uptime": uint64(60),
+	})
+	si.set(NewProvider("zeta", "Zeta", nil, nil), "z")
+	si.set(NewProvider("alpha", "Alpha", nil, nil), 1)
+	si.TimedOut = []string{"swap"}
+	// set without being collected, as by a library user
+	si.Kernel = "6.9.1-arch1-1"
+
+	b, err := json.Marshal(si)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fields in registration order, the extra ones sorted by name
+	// and the ones which weren't collected left out
+	want := `{"os":"Arch Linux","arch":"x86_64","uptime":60,` +
+		`"memory":{"used":1,"total":2},"paths":[],"alpha":1,"zeta":"z","timed_out":["swap"]}`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+
+	if _, ok := si.Field("kernel"); ok {
+		t.Error("got the hidden kernel field")
+	}
+	if v, ok := si.Field("arch"); !ok || v != "x86_64" {
+		t.Errorf("got arch %v, want x86_64", v)
+	}
+
+	if b, err := json.Marshal(SystemInfo{}); err != nil || string(b) != "{}" {
+		t.Errorf("got %s, %v with every field hidden, want {}", b, err)
+	}
+}
+
+func TestSystemInfoSet(t *testing.T) {
+	memory := &Usage{Used: 1, Total: 2}
+	si := infoOf(t, map[string]interface{}{"memory": memory})
+	extra := NewProvider("role", "Role", nil, nil)
+	si.set(extra, "build server")
+
+	// the built-in values go to their typed fields and the others to Extra
+	if si.Memory != memory {
+		t.Errorf("got memory %v, want %v", si.Memory, memory)
+	}
+	if _, ok := si.Extra["memory"]; ok || si.Extra["role"] != "build server" {
+		t.Errorf("got extra values %v, want role alone", si.Extra)
+	}
+
+	for name, want := range map[string]interface{}{"memory": memory, "role": "build server"} {
+		if v, ok := si.Value(name); !ok || v != want {
+			t.Errorf("got %s %v, want %v", name, v, want)
+		}
+	}
+	if _, ok := si.Value("swap"); ok {
+		t.Error("got a value for swap, which wasn't collected")
+	}
+}
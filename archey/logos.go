@@ -0,0 +1,606 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Logo is an ASCII art logo. The placeholders ${c1} to ${c9}
+// in Art switch to the Nth color of the logo body. The info
+// lines are placed to the right of the widest line of Art.
+type Logo struct {
+	// Name is used to select the logo with --logo
+	Name string
+	// Distro is the pretty name of the distribution
+	Distro string
+	// IDs are the os-release ID values the logo is used for
+	IDs []string
+	Art string
+}
+
+var ErrUnknownLogo = func(n string) error {
+	return fmt.Errorf("unknown logo '%s'", n)
+}
+
+var ErrDuplicateLogo = func(n string) error {
+	return fmt.Errorf("logo '%s' is already registered", n)
+}
+
+// colorPlaceholder matches ${c1} to ${c9} in logo art
+var colorPlaceholder = regexp.MustCompile(`\$\{c([1-9])\}`)
+
+// number of spaces between the logo and the info lines
+const logoGap = 4
+
+// os-release locations in order of precedence
+var osReleaseFiles = []string{
+	"/etc/os-release",
+	"/usr/lib/os-release",
+}
+
+var (
+	logosMu sync.RWMutex
+	logos   []Logo
+)
+
+func init() {
+	RegisterLogo(archLogo)
+	RegisterLogo(debianLogo)
+	RegisterLogo(ubuntuLogo)
+	RegisterLogo(linuxmintLogo)
+	RegisterLogo(fedoraLogo)
+	RegisterLogo(opensuseLogo)
+	RegisterLogo(alpineLogo)
+	RegisterLogo(voidLogo)
+	RegisterLogo(nixosLogo)
+	RegisterLogo(gentooLogo)
+	RegisterLogo(manjaroLogo)
+	RegisterLogo(endeavourosLogo)
+	RegisterLogo(tuxLogo)
+}
+
+// RegisterLogo adds l to the list of available logos.
+// It panics if a logo with the same name is already registered.
+func RegisterLogo(l Logo) {
+	logosMu.Lock()
+	defer logosMu.Unlock()
+
+	for _, r := range logos {
+		if r.Name == l.Name {
+			panic(ErrDuplicateLogo(l.Name))
+		}
+	}
+
+	logos = append(logos, l)
+}
+
+// Logos returns all registered logos in registration order
+func Logos() []Logo {
+	logosMu.RLock()
+	defer logosMu.RUnlock()
+
+	sl := make([]Logo, len(logos))
+	copy(sl, logos)
+	return sl
+}
+
+// LookupLogo returns the logo registered under name
+func LookupLogo(name string) (Logo, bool) {
+	logosMu.RLock()
+	defer logosMu.RUnlock()
+
+	for _, l := range logos {
+		if l.Name == name {
+			return l, true
+		}
+	}
+
+	return Logo{}, false
+}
+
+// SelectLogo returns the logo of the distribution identified
+// by the os-release ID, falling back to the distributions in
+// ID_LIKE and then to the generic Tux logo
+func SelectLogo(id string, idLike []string) Logo {
+	logosMu.RLock()
+	defer logosMu.RUnlock()
+
+	for _, want := range append([]string{id}, idLike...) {
+		for _, l := range logos {
+			for _, lid := range l.IDs {
+				if lid == want {
+					return l
+				}
+			}
+		}
+	}
+
+	return tuxLogo
+}
+
+// DetectLogo returns the logo of the distribution installed at sysroot,
+// the running one when sysroot is empty
+func DetectLogo(sysroot string) Logo {
+	o := &Options{Sysroot: sysroot}
+	return detectLogo(o.paths(osReleaseFiles))
+}
+
+// detectLogo returns the logo of the distribution
+// described by the first readable os-release file
+func detectLogo(files []string) Logo {
+	osr, err := readOSRelease(files...)
+	if err != nil {
+		return tuxLogo
+	}
+	return SelectLogo(osr["ID"], strings.Fields(osr["ID_LIKE"]))
+}
+
+// LoadLogo reads a logo from the ASCII art file f.
+// The logo is named after the file without its extension.
+func LoadLogo(f string) (Logo, error) {
+	b, err := ioutil.ReadFile(f)
+	if err != nil {
+		return Logo{}, err
+	}
+
+	if len(strings.TrimSpace(string(b))) == 0 {
+		return Logo{}, ErrFileEmpty(f)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(strings.TrimRight(line, "\r"))
+	}
+
+	return Logo{
+		Name: strings.TrimSuffix(filepath.Base(f), filepath.Ext(f)),
+		Art:  strings.Join(lines, "\n"),
+	}, nil
+}
+
+// Lines returns the lines of the logo art
+func (l Logo) Lines() []string {
+	return strings.Split(strings.TrimPrefix(l.Art, "\n"), "\n")
+}
+
+// Width returns the visible width of the widest line of the logo
+func (l Logo) Width() int {
+	var width int
+	for _, line := range l.Lines() {
+		if w := visibleWidth(line); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// visibleWidth returns the number of columns line takes
+// once the color placeholders are removed
+func visibleWidth(line string) int {
+	return utf8.RuneCountInString(colorPlaceholder.ReplaceAllString(line, ""))
+}
+
+// colorize replaces the color placeholders of line with the escape
+// codes of the body colors. Placeholders past the last color use it.
+func colorize(line string, codes []string) string {
+	return colorPlaceholder.ReplaceAllStringFunc(line, func(p string) string {
+		n, _ := strconv.Atoi(colorPlaceholder.FindStringSubmatch(p)[1])
+		if n > len(codes) {
+			n = len(codes)
+		}
+		return codes[n-1]
+	})
+}
+
+// expandTabs replaces the tabs of line with spaces up to the next tab stop
+func expandTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+
+	var buf strings.Builder
+	var col int
+	for _, r := range line {
+		if r == '\t' {
+			n := 8 - col%8
+			buf.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		buf.WriteRune(r)
+		col++
+	}
+	return buf.String()
+}
+
+// logo returns the logo set by the options or the detected one
+func (o *Options) logo() (Logo, error) {
+	if o.LogoFile != "" {
+		return LoadLogo(o.LogoFile)
+	}
+
+	if o.Logo == "" {
+		return detectLogo(o.paths(osReleaseFiles)), nil
+	}
+
+	l, ok := LookupLogo(o.Logo)
+	if !ok {
+		return Logo{}, ErrUnknownLogo(o.Logo)
+	}
+	return l, nil
+}
+
+// readOSRelease parses the first os-release file that can be read
+// into a map of its variables with the quotes removed
+func readOSRelease(files ...string) (map[string]string, error) {
+	var err error
+	for _, f := range files {
+		var file *os.File
+		file, err = os.Open(f)
+		if err != nil {
+			continue
+		}
+		defer file.Close()
+
+		osr := make(map[string]string)
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			fields := strings.SplitN(line, "=", 2)
+			if len(fields) != 2 {
+				continue
+			}
+			osr[fields[0]] = strings.Trim(fields[1], "\"'")
+		}
+
+		return osr, scanner.Err()
+	}
+
+	return nil, err
+}
+
+var archLogo = Logo{
+	Name:   "arch",
+	Distro: "Arch Linux",
+	IDs:    []string{"arch", "archarm"},
+	Art: `
+                  ${c1}##
+                 ${c1}####
+                ${c1}######
+               ${c1}########
+              ${c1}##########
+             ${c1}############
+            ${c1}##############
+           ${c1}################
+          ${c1}##################
+         ${c1}#########${c2}########${c1}###
+        ${c1}###${c2}#################${c1}##
+       ${c1}##${c2}#######      ${c2}#########
+      ${c2}########;        ${c2};########
+     ${c2}########;          ${c2};########
+    ${c2}##########.        ${c2}.##########
+   ${c2}#######                  ${c2}#######
+  ${c2}#####                        ${c2}#####
+ ${c2}###                              ${c2}###
+${c2}##                                  ${c2}##`,
+}
+
+var debianLogo = Logo{
+	Name:   "debian",
+	Distro: "Debian",
+	IDs:    []string{"debian"},
+	Art: `
+${c1}       _,met$$$$$gg.
+${c1}    ,g$$$$$$$$$$$$$$$P.
+${c1}  ,g$$P"         """Y$$.".
+${c1} ,$$P'              '$$$.
+${c1}',$$P       ,ggs.     '$$b:
+${c1}'d$$'     ,$P"'   ${c2}.${c1}    $$$
+${c1} $$P      d$'     ${c2},${c1}    $$P
+${c1} $$:      $$.   ${c2}-${c1}    ,d$$'
+${c1} $$;      Y$b._   _,d$P'
+${c1} Y$$.    ${c2}'.${c1}'"Y$$$$P"'
+${c1} '$$b      ${c2}"-.__
+${c1}  'Y$$
+${c1}   'Y$$.
+${c1}     '$$b.
+${c1}       'Y$$b.
+${c1}          '"Y$b._
+${c1}              '"""`,
+}
+
+var ubuntuLogo = Logo{
+	Name:   "ubuntu",
+	Distro: "Ubuntu",
+	IDs:    []string{"ubuntu"},
+	Art: `
+${c1}            .-/+oossssoo+/-.
+${c1}        ':+ssssssssssssssssss+:'
+${c1}      -+ssssssssssssssssssyyssss+-
+${c1}    .ossssssssssssssssss${c2}dMMMNy${c1}sssso.
+${c1}   /sssssssssss${c2}hdmmNNmmyNMMMMh${c1}ssssss/
+${c1}  +sssssssss${c2}hmydMMMMMMMNddddy${c1}ssssssss+
+${c1} /ssssssss${c2}hNMMMyhhyyyyhmNMMMNh${c1}ssssssss/
+${c1}.ssssssss${c2}dMMMNh${c1}ssssssssss${c2}hNMMMd${c1}ssssssss.
+${c1}+ssss${c2}hhhyNMMNy${c1}ssssssssssss${c2}yNMMMy${c1}sssssss+
+${c1}oss${c2}yNMMMNyMMh${c1}ssssssssssssss${c2}hmmmh${c1}ssssssso
+${c1}oss${c2}yNMMMNyMMh${c1}ssssssssssssss${c2}hmmmh${c1}ssssssso
+${c1}+ssss${c2}hhhyNMMNy${c1}ssssssssssss${c2}yNMMMy${c1}sssssss+
+${c1}.ssssssss${c2}dMMMNh${c1}ssssssssss${c2}hNMMMd${c1}ssssssss.
+${c1} /ssssssss${c2}hNMMMyhhyyyyhdNMMMNh${c1}ssssssss/
+${c1}  +sssssssss${c2}dmydMMMMMMMMddddy${c1}ssssssss+
+${c1}   /sssssssssss${c2}hdmNNNNmyNMMMMh${c1}ssssss/
+${c1}    .ossssssssssssssssss${c2}dMMMNy${c1}sssso.
+${c1}      -+sssssssssssssssss${c2}yyy${c1}ssss+-
+${c1}        ':+ssssssssssssssssss+:'
+${c1}            .-/+oossssoo+/-.`,
+}
+
+var fedoraLogo = Logo{
+	Name:   "fedora",
+	Distro: "Fedora",
+	IDs:    []string{"fedora"},
+	Art: `
+${c1}          /:-------------:\
+${c1}       :-------------------::
+${c1}     :-----------${c2}/shhOHbmp${c1}---:\
+${c1}   /-----------${c2}omMMMNNNMMD${c1}  ---:
+${c1}  :-----------${c2}sMMMMNMNMP${c1}.    ---:
+${c1} :-----------${c2}:MMMdP${c1}-------    ---\
+${c1},------------${c2}:MMMd${c1}--------    ---:
+${c1}:------------${c2}:MMMd${c1}-------    .---:
+${c1}:----    ${c2}oNMMMMMMMMMNho${c1}     .----:
+${c1}:--     .${c2}+shhhMMMmhhy++${c1}   .------/
+${c1}:-    -------${c2}:MMMd${c1}--------------:
+${c1}:-   --------${c2}/MMMd${c1}-------------;
+${c1}:-    ------${c2}/hMMMy${c1}------------:
+${c1}:--${c2} :dMNdhhdNMMNo${c1}------------;
+${c1}:---${c2}:sdNMMMMNds:${c1}------------:
+${c1}:------${c2}:://:${c1}-------------::
+${c1}:---------------------://`,
+}
+
+var opensuseLogo = Logo{
+	Name:   "opensuse",
+	Distro: "openSUSE",
+	IDs:    []string{"opensuse", "opensuse-leap", "opensuse-tumbleweed", "suse"},
+	Art: `
+${c1}           .;ldkO0000Okdl;.
+${c1}       .;d00xl:^''''''^:ok00d;.
+${c1}     .d00l'                'o00d.
+${c1}   .d0Kd'${c2}  Okxol:;,.          ${c1}:O0d.
+${c1}  .OK${c2}KKK0kOKKKKKKKKKKOxo:,      ${c1}lKO.
+${c1} ,0K${c2}KKKKKKKKKKKKKKK0P^,,,${c1}^dx:${c2}    ${c1};00,
+${c1}.OK${c2}KKKKKKKKKKKKKKKk'.oOPPb.${c1}'0k.${c2}   ${c1}cKO.
+${c1}:KK${c2}KKKKKKKKKKKKKKK: kKx..dd ${c1}lKd${c2}   ${c1}'OK:
+${c1}dKK${c2}KKKKKKKKKOx0KKKd ${c1}^0KKKO' ${c2}kKKc${c1}   dKd
+${c1}dKK${c2}KKKKKKKKKK;.;oOKx,..${c1}^${c2}..;kKKK0.${c1}  dKd
+${c1}:KK${c2}KKKKKKKKKK0o;...^cdxxOK0O/^^'${c1}  .0K:
+${c1} kKK${c2}KKKKKKKKKKKKK0x;,,......,;od${c1}  lKk
+${c1} '0K${c2}KKKKKKKKKKKKKKKKKKKK00KKOo^${c1}  c00'
+${c1}  'kK${c2}KKOxddxkOO00000Okxoc;''${c1}   .dKk'
+${c1}    l0Ko.                    .c00l'
+${c1}     'l0Kk:.              .;xK0l'
+${c1}        'lkK0xl:;,,,,;:ldO0kl'
+${c1}            '^:ldxkkkkxdl:^'`,
+}
+
+var alpineLogo = Logo{
+	Name:   "alpine",
+	Distro: "Alpine Linux",
+	IDs:    []string{"alpine"},
+	Art: `
+${c1}       .hddddddddddddddddddddddh.
+${c1}      :dddddddddddddddddddddddddd:
+${c1}     /dddddddddddddddddddddddddddd/
+${c1}    +dddddddddddddddddddddddddddddd+
+${c1}  'sdddddddddddddddddddddddddddddddds'
+${c1} 'ydddddddddddd++hdddddddddddddddddddy'
+${c1}.hddddddddddd+'  '+ddddh:-sdddddddddddh.
+${c1}hdddddddddd+'      '+y:    .sddddddddddh
+${c1}ddddddddh+'   '//'   '.'     -sddddddddd
+${c1}ddddddh+'   '/hddh/'   '+:    -sddddddd
+${c1}ddddh+'   '/+/dddddh/'   '+s-    -sddddd
+${c1}ddd+'   '/o' :dddddddh/'   'oy-    .yddd
+${c1}hdddyo+ohddyosdddddddddho+oydddy++ohdddh
+${c1}.hddddddddddddddddddddddddddddddddddddh.
+${c1} 'yddddddddddddddddddddddddddddddddddy'
+${c1}  'sdddddddddddddddddddddddddddddddds'
+${c1}    +dddddddddddddddddddddddddddddd+
+${c1}     /dddddddddddddddddddddddddddd/
+${c1}      :dddddddddddddddddddddddddd:
+${c1}       .hddddddddddddddddddddddh.`,
+}
+
+var voidLogo = Logo{
+	Name:   "void",
+	Distro: "Void Linux",
+	IDs:    []string{"void"},
+	Art: `
+${c1}                __.;=====;.__
+${c1}            _.=+==++=++=+=+===;.
+${c1}             -=+++=+===+=+=+++++=_
+${c1}        .     -=:''     '--=+=++=.
+${c1}       _vi,    '            --+=++++:
+${c1}      .uvnvi.       _._       -==+==+.
+${c1}     .vvnvnI'    .;==|==;.     :|=||=|.
+${c1}${c2}+QmQQm${c1}pvvnv; ${c2}_yYsyQQWUUQQQm #QmQ#${c1}:${c2}QQQWUV$QQm.
+${c2} -QQWQW${c1}pvvo${c2}wZ?.wQQQE${c1}==<${c2}QWWQ/QWQW.QQWW${c1}(: ${c2}jQWQE
+${c2}  -$QQQQmmU'  jQQQ@${c1}+=<${c2}QWQQ)mQQQ.mQQQC${c1}+;${c2}jWQQ@'
+${c2}   -$WQ8Y${c1}nI:   ${c2}QWQQwgQQWV${c1}'${c2}mWQQ.jQWQQgyyWW@!
+${c1}     -1vvnvv.     '~+++'        ++|+++
+${c1}      +vnvnnv,                 '-|===
+${c1}       +vnvnvns.           .      :=-
+${c1}        -Invnvvnsi..___..=sv=.     '
+${c1}          +Invnvnvnnnnnnnnvvnn;.
+${c1}            ~|Invnvnvvnvvvnnv}+'
+${c1}               -~|{*l}*|~`,
+}
+
+var nixosLogo = Logo{
+	Name:   "nixos",
+	Distro: "NixOS",
+	IDs:    []string{"nixos"},
+	Art: `
+${c1}          ::::.    ${c2}':::::     ::::'
+${c1}          '::::     ${c2}':::::.  ::::'
+${c1}            :::::     ${c2}'::::.::::'
+${c1}      :::::::::::::::::::${c2}'::::::'${c1}    .:
+${c1}     ::::::::::::::::::::::${c2}'::::.${c1}  .:::
+${c2}            .....           ${c2}::::' ${c1}:::::
+${c2}           ::::'             ${c2}':::${c1}::::'
+${c2}     .....::::'               ${c1}':::::::::::.
+${c2}     ':::::::::               ${c1}::::::::::::
+${c2}         .::::.               ${c1}::::'
+${c2}        .:::${c1}::::.             ${c1}.::::'
+${c2}         ':: ${c1}:::::.           ${c1}'''''
+${c2}          '  ${c1}'::::::::::::::::::::::::
+${c2}             ${c1}.::::::::::::::::::::::::
+${c2}            ::::'   ${c1}'::::.
+${c2}           '::::'     ${c1}'::::.
+${c2}          .::::'       ${c1}'::::.`,
+}
+
+var gentooLogo = Logo{
+	Name:   "gentoo",
+	Distro: "Gentoo",
+	IDs:    []string{"gentoo"},
+	Art: `
+${c1}         -/oyddmdhs+:.
+${c1}     -o${c2}dNMMMMMMMMNNmhy+${c1}-'
+${c1}   -y${c2}NMMMMMMMMMMMNNNmmdhy${c1}+-
+${c1} 'o${c2}mMMMMMMMMMMMMNmdmmmmddhhy${c1}/'
+${c1} om${c2}MMMMMMMMMMMN${c1}hhyyyo${c2}hmdddhhhd${c1}o'
+${c1}.y${c2}dMMMMMMMMMMd${c1}hs++so/s${c2}mdddhhhhdm${c1}+'
+${c1} oy${c2}hdmNMMMMMMMN${c1}dyooy${c2}dmddddhhhhyhN${c1}d.
+${c1}  :o${c2}yhhdNNMMMMMMMNNNmmdddhhhhhyym${c1}Mh
+${c1}    .:${c2}+sydNMMMMMNNNmmmdddhhhhhhmM${c1}my
+${c1}       /m${c2}MMMMMMNNNmmmdddhhhhhmMNh${c1}s:
+${c1}    'o${c2}NMMMMMMMNNNmmmddddhhdmMNhs${c1}+'
+${c1}  'sh${c2}MMMMMMMMNNNmmmdddddmNMmhs${c1}/.
+${c1} /N${c2}MMMMMMMMNNNNmmmdddmNMNdso${c1}:'
+${c1}+M${c2}MMMMMMNNNNNmmmmdmNMNdso${c1}/-
+${c1}yM${c2}MNNNNNNNmmmmmNNMmhs+/${c1}-'
+${c1}/h${c2}MMNNNNNNNNMNdhs++/${c1}-'
+${c1}'/${c2}ohdmmddhys+++/:${c1}.'
+${c1}  '-//////:--.`,
+}
+
+var manjaroLogo = Logo{
+	Name:   "manjaro",
+	Distro: "Manjaro",
+	IDs:    []string{"manjaro", "manjaro-arm"},
+	Art: `
+${c1}||||||||||||||||||  ||||||||
+${c1}||||||||||||||||||  ||||||||
+${c1}||||||||||||||||||  ||||||||
+${c1}||||||||||||||||||  ||||||||
+${c1}||||||||            ||||||||
+${c1}||||||||  ||||||||  ||||||||
+${c1}||||||||  ||||||||  ||||||||
+${c1}||||||||  ||||||||  ||||||||
+${c1}||||||||  ||||||||  ||||||||
+${c1}||||||||  ||||||||  ||||||||
+${c1}||||||||  ||||||||  ||||||||
+${c1}||||||||  ||||||||  ||||||||
+${c1}||||||||  ||||||||  ||||||||
+${c1}||||||||  ||||||||  ||||||||`,
+}
+
+var endeavourosLogo = Logo{
+	Name:   "endeavouros",
+	Distro: "EndeavourOS",
+	IDs:    []string{"endeavouros"},
+	Art: `
+${c1}                     ./${c2}o${c1}.
+${c1}                   ./${c2}sssso${c1}-
+${c1}                 ':${c2}osssssss+${c1}-
+${c1}               ':${c2}+sssssssssso${c1}/.
+${c1}             '-/o${c2}ssssssssssssso${c1}/.
+${c1}           '-/+${c2}sssssssssssssssso${c1}+:'
+${c1}         '-:/+${c2}sssssssssssssssssso${c1}+/.
+${c1}       '.://o${c2}sssssssssssssssssssso${c1}++-
+${c1}      .://+${c2}ssssssssssssssssssssssso${c1}++:
+${c1}    .:///o${c2}ssssssssssssssssssssssssso${c1}++:
+${c1}  ':////${c2}ssssssssssssssssssssssssssso${c1}+++.
+${c1}'-////+${c2}ssssssssssssssssssssssssssso${c1}++++-
+${c1} '..-+${c2}oosssssssssssssssssssssssso${c1}+++++/'
+${c2}   ./++++++++++++++++++++++++++++++/:.
+${c2}  ':::::::::::::::::::::::::------''`,
+}
+
+var linuxmintLogo = Logo{
+	Name:   "linuxmint",
+	Distro: "Linux Mint",
+	IDs:    []string{"linuxmint"},
+	Art: `
+${c1}MMMMMMMMMMMMMMMMMMMMMMMMMmds+.
+${c1}MMm----::-://////////////oymNMd+'
+${c1}MMd      ${c2}/++                ${c1}-sNMd:
+${c1}MMNso/'  ${c2}dMM    '.::-. .-::.' ${c1}.hMN:
+${c1}ddddMMh  ${c2}dMM   :hNMNMNhNMNMNh: ${c1}'NMm
+${c1}    NMm  ${c2}dMM  .NMN/-+MMM+-/NMN' ${c1}dMM
+${c1}    NMm  ${c2}dMM  -MMm  'MMM   dMM. ${c1}dMM
+${c1}    NMm  ${c2}dMM  -MMm  'MMM   dMM. ${c1}dMM
+${c1}    NMm  ${c2}dMM  .mmd  'mmm   yMM. ${c1}dMM
+${c1}    NMm  ${c2}dMM'  ..'   ...   ydm. ${c1}dMM
+${c1}    hMM- ${c2}+MMd/-------...-:sdds  ${c1}dMM
+${c1}    -NMm- ${c2}:hNMNNNmdddddddddy/'  ${c1}dMM
+${c1}     -dMNs-${c2}''-::::-------.''    ${c1}dMM
+${c1}      '/dMNmy+/:-------------:/yMMM
+${c1}         ./ydNMMMMMMMMMMMMMMMMMMMMM
+${c1}            .MMMMMMMMMMMMMMMMMMM`,
+}
+
+var tuxLogo = Logo{
+	Name:   "tux",
+	Distro: "Linux",
+	IDs:    []string{},
+	Art: `
+${c1}         #####
+${c1}        #######
+${c1}        ##${c2}O${c1}#${c2}O${c1}##
+${c1}        #${c2}#####${c1}#
+${c1}      ##${c2}##${c1}###${c2}##${c1}##
+${c1}     #${c2}##########${c1}##
+${c1}    #${c2}############${c1}##
+${c1}    #${c2}############${c1}###
+${c2}   ##${c1}#${c2}###########${c1}##${c2}#
+${c2} ######${c1}#${c2}#######${c1}#${c2}######
+${c2} #######${c1}#${c2}#####${c1}#${c2}#######
+${c2}   #####${c1}#######${c2}#####`,
+}
@@ -0,0 +1,266 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bufio"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	sysNet       = "/sys/class/net"
+	procNetRoute = "/proc/net/route"
+)
+
+// ARPHRD_* link types of /sys/class/net/<iface>/type
+const (
+	arphrdEther    = 1
+	arphrdLoopback = 772
+	arphrdNone     = 65534
+)
+
+// IFF_UP flag of /sys/class/net/<iface>/flags
+const iffUp = 0x1
+
+// Network holds the active network interfaces and the default gateway
+type Network struct {
+	Interfaces []Interface `json:"interfaces"`
+	Gateway    string      `json:"gateway"`
+	// GatewayInterface is the interface the default route goes through
+	GatewayInterface string `json:"gateway_interface"`
+}
+
+// Interface is an active network interface
+type Interface struct {
+	Name string `json:"name"`
+	// Type is one of ethernet, wifi, bridge, wireguard, tun, vlan or other
+	Type  string `json:"type"`
+	State string `json:"state"`
+	// Addresses holds the IPv4 and IPv6 addresses in CIDR notation
+	Addresses []string `json:"addresses"`
+}
+
+// getNetwork returns the active non-loopback interfaces of the system mounted
+// at root whose names match filter, along with the default gateway. The
+// addresses of an interface are looked up through addrs.
+func getNetwork(root string, filter []string, addrs func(string) []string) (*Network, error) {
+	dirs, err := filepath.Glob(filepath.Join(root, sysNet, "*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+
+	network := &Network{Interfaces: []Interface{}}
+	for _, dir := range dirs {
+		name := filepath.Base(dir)
+		if !matchInterface(name, filter) {
+			continue
+		}
+
+		read := func(f string) string {
+			s, _ := readFirstLine(filepath.Join(dir, f))
+			return s
+		}
+
+		linkType, _ := strconv.Atoi(read("type"))
+		flags, _ := strconv.ParseUint(strings.TrimPrefix(read("flags"), "0x"), 16, 64)
+		state := read("operstate")
+		if linkType == arphrdLoopback || flags&iffUp == 0 || state == "down" ||
+			state == "lowerlayerdown" || state == "notpresent" {
+			continue
+		}
+
+		iface := Interface{
+			Name:      name,
+			Type:      interfaceType(dir, linkType),
+			State:     state,
+			Addresses: []string{},
+		}
+		if a := addrs(name); a != nil {
+			iface.Addresses = a
+		}
+		network.Interfaces = append(network.Interfaces, iface)
+	}
+
+	network.Gateway, network.GatewayInterface = defaultGateway(filepath.Join(root, procNetRoute))
+
+	return network, nil
+}
+
+// interfaceType returns the type of the interface at dir in /sys/class/net
+func interfaceType(dir string, linkType int) string {
+	exists := func(f string) bool {
+		_, err := os.Stat(filepath.Join(dir, f))
+		return err == nil
+	}
+
+	devType := readKeyValue(filepath.Join(dir, "uevent"), "DEVTYPE")
+	switch {
+	case exists("wireless") || exists("phy80211") || devType == "wlan":
+		return "wifi"
+	case exists("bridge") || devType == "bridge":
+		return "bridge"
+	case devType == "wireguard":
+		return "wireguard"
+	case devType == "vlan":
+		return "vlan"
+	case exists("tun_flags"):
+		return "tun"
+	case linkType == arphrdEther:
+		return "ethernet"
+	}
+	return "other"
+}
+
+// matchInterface reports whether name matches filter, a list of glob patterns
+// where the ones starting with ! exclude interfaces. An empty filter or one
+// made only of exclusions matches every interface that isn't excluded.
+func matchInterface(name string, filter []string) bool {
+	included := true
+	for _, pattern := range filter {
+		if !strings.HasPrefix(pattern, "!") {
+			included = false
+			break
+		}
+	}
+
+	for _, pattern := range filter {
+		exclude := strings.HasPrefix(pattern, "!")
+		if ok, _ := filepath.Match(strings.TrimPrefix(pattern, "!"), name); ok {
+			if exclude {
+				return false
+			}
+			included = true
+		}
+	}
+
+	return included
+}
+
+// defaultGateway returns the gateway of the default route in the route
+// table f and the interface it goes through
+func defaultGateway(f string) (string, string) {
+	file, err := os.Open(f)
+	if err != nil {
+		return "", ""
+	}
+	defer file.Close()
+
+	// Iface Destination Gateway Flags ..., addresses are hex in host byte order
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[1] != "00000000" {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(fields[3], 16, 64)
+		if err != nil || flags&0x2 == 0 { // RTF_GATEWAY
+			continue
+		}
+
+		b, err := hex.DecodeString(fields[2])
+		if err != nil || len(b) != net.IPv4len {
+			continue
+		}
+		return net.IPv4(b[3], b[2], b[1], b[0]).String(), fields[0]
+	}
+
+	return "", ""
+}
+
+// interfaceAddrs returns the addresses of the interface name
+// of the running system in CIDR notation
+func interfaceAddrs(name string) []string {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil
+	}
+
+	var sl []string
+	for _, addr := range addrs {
+		sl = append(sl, addr.String())
+	}
+	return sl
+}
+
+// maskAddress hides the host part of an address, keeping the first 16 bits
+// of IPv4 and the first 32 bits of IPv6 addresses, e.g. 192.168.x.x/24 and
+// 2001:db8:x:x:x:x:x:x/64. The groups of IPv6 addresses are all written
+// out, as the :: of the compressed form could stand for the masked ones.
+func maskAddress(addr string) string {
+	ip, prefix := addr, ""
+	if i := strings.IndexByte(addr, '/'); i >= 0 {
+		ip, prefix = addr[:i], addr[i:]
+	}
+
+	parsed := net.ParseIP(ip)
+	switch {
+	case parsed == nil:
+		return addr
+	case parsed.To4() != nil:
+		octets := strings.Split(parsed.To4().String(), ".")
+		return octets[0] + "." + octets[1] + ".x.x" + prefix
+	}
+
+	groups := []string{"x", "x", "x", "x", "x", "x", "x", "x"}
+	for i := 0; i < 2; i++ {
+		groups[i] = strconv.FormatUint(uint64(parsed[2*i])<<8|uint64(parsed[2*i+1]), 16)
+	}
+	return strings.Join(groups, ":") + prefix
+}
+
+// mask returns a copy of n with masked addresses
+func (n *Network) mask() *Network {
+	masked := &Network{
+		Interfaces:       make([]Interface, len(n.Interfaces)),
+		GatewayInterface: n.GatewayInterface,
+	}
+	if n.Gateway != "" {
+		masked.Gateway = maskAddress(n.Gateway)
+	}
+	for i, iface := range n.Interfaces {
+		iface.Addresses = make([]string, len(n.Interfaces[i].Addresses))
+		for j, addr := range n.Interfaces[i].Addresses {
+			iface.Addresses[j] = maskAddress(addr)
+		}
+		masked.Interfaces[i] = iface
+	}
+	return masked
+}
+
+// GetNetwork returns the active network interfaces
+// whose names match filter and the default gateway
+func GetNetwork(filter []string) (*Network, error) {
+	return getNetwork("/", filter, interfaceAddrs)
+}
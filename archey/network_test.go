@@ -0,0 +1,138 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatchInterface(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter []string
+		want   bool
+	}{
+		{"enp3s0", nil, true},
+		{"enp3s0", []string{"en*"}, true},
+		{"wlan0", []string{"en*"}, false},
+		{"wlan0", []string{"en*", "wl*"}, true},
+		{"docker0", []string{"!docker*"}, false},
+		{"enp3s0", []string{"!docker*", "!veth*"}, true},
+		{"enp3s0", []string{"en*", "!enp3s0"}, false},
+		{"enp3s0", []string{"!enp3s0", "en*"}, false},
+		{"eth0", []string{"en*", "!enp3s0"}, false},
+		{"enp3s0", []string{"[invalid"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := matchInterface(tt.name, tt.filter); got != tt.want {
+			t.Errorf("matchInterface(%q, %q) = %v, want %v", tt.name, tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestMaskAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{"192.168.1.23/24", "192.168.x.x/24"},
+		{"10.0.0.1", "10.0.x.x"},
+		{"::ffff:192.168.1.23", "192.168.x.x"},
+		{"2001:db8:85a3::8a2e:370:7334/64", "2001:db8:x:x:x:x:x:x/64"},
+		{"fe80::1c2b:3fff:fe4d:5e6f/64", "fe80:0:x:x:x:x:x:x/64"},
+		{"::1/128", "0:0:x:x:x:x:x:x/128"},
+		{"2a02:0810:0d40::1", "2a02:810:x:x:x:x:x:x"},
+		{"not an address", "not an address"},
+	}
+
+	for _, tt := range tests {
+		if got := maskAddress(tt.addr); got != tt.want {
+			t.Errorf("maskAddress(%q) = %q, want %q", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultGateway(t *testing.T) {
+	tests := []struct {
+		file         string
+		gateway, dev string
+	}{
+		// the first default route with RTF_GATEWAY set and a valid address
+		{"testdata/network/route", "10.0.1.254", "wlan0"},
+		{"testdata/network/route-local", "", ""},
+		{filepath.Join(sysroot, procNetRoute), "192.168.1.1", "enp3s0"},
+		{"testdata/network/missing", "", ""},
+	}
+
+	for _, tt := range tests {
+		gateway, dev := defaultGateway(tt.file)
+		if gateway != tt.gateway || dev != tt.dev {
+			t.Errorf("%s: got %q via %q, want %q via %q", tt.file, gateway, dev, tt.gateway, tt.dev)
+		}
+	}
+}
+
+func TestGetNetwork(t *testing.T) {
+	addrs := func(name string) []string {
+		if name == "enp3s0" {
+			return []string{"192.168.1.23/24", "2001:db8:85a3::8a2e:370:7334/64"}
+		}
+		return nil
+	}
+
+	got, err := getNetwork(sysroot, []string{"!docker*"}, addrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the loopback and the interfaces which are down are left out
+	want := &Network{
+		Interfaces: []Interface{
+			{Name: "enp3s0", Type: "ethernet", State: "up",
+				Addresses: []string{"192.168.1.23/24", "2001:db8:85a3::8a2e:370:7334/64"}},
+			{Name: "wlan0", Type: "wifi", State: "up", Addresses: []string{}},
+		},
+		Gateway:          "192.168.1.1",
+		GatewayInterface: "enp3s0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	want.Gateway = "192.168.x.x"
+	want.Interfaces[0].Addresses = []string{"192.168.x.x/24", "2001:db8:x:x:x:x:x:x/64"}
+	if masked := got.mask(); !reflect.DeepEqual(masked, want) {
+		t.Errorf("got masked %+v, want %+v", masked, want)
+	}
+
+	got, err = getNetwork(sysroot, []string{"wl*"}, addrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Interfaces) != 1 || got.Interfaces[0].Name != "wlan0" {
+		t.Errorf("got %+v, want wlan0 alone", got.Interfaces)
+	}
+}
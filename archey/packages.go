@@ -0,0 +1,362 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	utils "github.com/alexdreptu/utils-go"
+)
+
+// PackageCount is the number of packages installed by a package manager
+type PackageCount struct {
+	Manager string `json:"manager"`
+	Count   int    `json:"count"`
+}
+
+// packageManager counts the packages installed on the system
+// whose root file system is root and user's home directory is home
+type packageManager struct {
+	name  string
+	count func(root, home string) (int, error)
+}
+
+// package managers in the order they are displayed
+var packageManagers = []packageManager{
+	{"pacman", countPacman},
+	{"dpkg", countDpkg},
+	{"rpm", countRpm},
+	{"apk", countApk},
+	{"xbps", countXbps},
+	{"portage", countPortage},
+	{"nix", countNix},
+	{"flatpak", countFlatpak},
+	{"snap", countSnap},
+}
+
+// package databases relative to the root file system
+const (
+	dpkgStatus    = "/var/lib/dpkg/status"
+	apkInstalled  = "/lib/apk/db/installed"
+	xbpsDir       = "/var/db/xbps"
+	portageDir    = "/var/db/pkg"
+	nixProfile    = "/nix/var/nix/profiles/default"
+	flatpakAppDir = "/var/lib/flatpak/app"
+	snapDir       = "/snap"
+)
+
+// rpm databases relative to the root file system, sqlite
+// is used by newer rpm versions and Berkeley DB by older ones
+var (
+	rpmSqlite = []string{
+		"/usr/lib/sysimage/rpm/rpmdb.sqlite",
+		"/var/lib/rpm/rpmdb.sqlite",
+	}
+	rpmBdb = []string{
+		"/usr/lib/sysimage/rpm/Packages",
+		"/var/lib/rpm/Packages",
+	}
+)
+
+// PackageManagers returns the names of the supported package managers
+func PackageManagers() []string {
+	var names []string
+	for _, pm := range packageManagers {
+		names = append(names, pm.name)
+	}
+	return names
+}
+
+// CountPackages returns the package count of every package manager
+// found on the system whose root file system is root, except the
+// ones in exclude. Package managers which aren't installed or whose
+// database can't be read are left out, and so are the packages of the
+// user when home is empty.
+func CountPackages(root, home string, exclude []string) []PackageCount {
+	var counts []PackageCount
+
+	for _, pm := range packageManagers {
+		if contains(exclude, pm.name) {
+			continue
+		}
+
+		n, err := pm.count(root, home)
+		if err != nil || n == 0 {
+			continue
+		}
+		counts = append(counts, PackageCount{Manager: pm.name, Count: n})
+	}
+
+	return counts
+}
+
+func contains(sl []string, s string) bool {
+	for _, v := range sl {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// countDirs returns the number of directories in dir
+func countDirs(dir string) (int, error) {
+	count, err := utils.CountDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	return count.Dirs, nil
+}
+
+func countPacman(root, home string) (int, error) {
+	return countDirs(filepath.Join(root, pacmanDir))
+}
+
+// countDpkg counts the installed packages in dpkg's status file
+func countDpkg(root, home string) (int, error) {
+	file, err := os.Open(filepath.Join(root, dpkgStatus))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var n int
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Status:") &&
+			strings.HasSuffix(line, " installed") {
+			n++
+		}
+	}
+
+	return n, scanner.Err()
+}
+
+func countRpm(root, home string) (int, error) {
+	for _, db := range rpmSqlite {
+		f := filepath.Join(root, db)
+		if utils.IsExistFile(f) {
+			return sqliteCountRows(f, "Packages")
+		}
+	}
+
+	for _, db := range rpmBdb {
+		f := filepath.Join(root, db)
+		if utils.IsExistFile(f) {
+			n, err := bdbCountRecords(f)
+			// the record with key 0 holds the next package instance
+			if n > 0 {
+				n--
+			}
+			return n, err
+		}
+	}
+
+	return 0, os.ErrNotExist
+}
+
+// countApk counts the package names in apk's installed database
+func countApk(root, home string) (int, error) {
+	return countLinePrefix(filepath.Join(root, apkInstalled), "P:")
+}
+
+// countXbps counts the packages in xbps' package database
+func countXbps(root, home string) (int, error) {
+	dbs, err := filepath.Glob(filepath.Join(root, xbpsDir, "pkgdb-*.plist"))
+	if err != nil {
+		return 0, err
+	}
+	if len(dbs) == 0 {
+		return 0, os.ErrNotExist
+	}
+
+	b, err := ioutil.ReadFile(dbs[0])
+	if err != nil {
+		return 0, err
+	}
+	return bytes.Count(b, []byte("<key>pkgver</key>")), nil
+}
+
+// countPortage counts the package directories of each category
+func countPortage(root, home string) (int, error) {
+	categories, err := ioutil.ReadDir(filepath.Join(root, portageDir))
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for _, c := range categories {
+		if !c.IsDir() {
+			continue
+		}
+
+		pkgs, err := countDirs(filepath.Join(root, portageDir, c.Name()))
+		if err != nil {
+			return 0, err
+		}
+		n += pkgs
+	}
+
+	return n, nil
+}
+
+// countNix counts the packages of the default and the user's nix profile
+func countNix(root, home string) (int, error) {
+	profiles := []string{filepath.Join(root, nixProfile)}
+	if home != "" {
+		profiles = append(profiles, filepath.Join(home, ".nix-profile"))
+	}
+
+	var n int
+	var found bool
+	for _, profile := range profiles {
+		pkgs, err := countNixProfile(profile)
+		if err != nil {
+			continue
+		}
+		found = true
+		n += pkgs
+	}
+
+	if !found {
+		return 0, os.ErrNotExist
+	}
+	return n, nil
+}
+
+// countNixProfile counts the elements of a nix profile's manifest.json
+// or the derivations of manifest.nix for profiles managed by nix-env
+func countNixProfile(profile string) (int, error) {
+	b, err := ioutil.ReadFile(filepath.Join(profile, "manifest.json"))
+	if err != nil {
+		b, err := ioutil.ReadFile(filepath.Join(profile, "manifest.nix"))
+		if err != nil {
+			return 0, err
+		}
+		return bytes.Count(b, []byte(`type = "derivation"`)), nil
+	}
+
+	var manifest struct {
+		Elements json.RawMessage `json:"elements"`
+	}
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return 0, err
+	}
+
+	// elements is a list up to version 2 of the manifest
+	// and an object keyed by name afterwards
+	var list []json.RawMessage
+	if err := json.Unmarshal(manifest.Elements, &list); err == nil {
+		return len(list), nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(manifest.Elements, &obj); err != nil {
+		return 0, err
+	}
+	return len(obj), nil
+}
+
+// countFlatpak counts the system wide and user wide installed applications
+func countFlatpak(root, home string) (int, error) {
+	dirs := []string{filepath.Join(root, flatpakAppDir)}
+	if home != "" {
+		dirs = append(dirs, filepath.Join(home, ".local/share/flatpak/app"))
+	}
+
+	var n int
+	var found bool
+	for _, dir := range dirs {
+		apps, err := countDirs(dir)
+		if err != nil {
+			continue
+		}
+		found = true
+		n += apps
+	}
+
+	if !found {
+		return 0, os.ErrNotExist
+	}
+	return n, nil
+}
+
+// countSnap counts the mounted snaps
+func countSnap(root, home string) (int, error) {
+	n, err := countDirs(filepath.Join(root, snapDir))
+	if err != nil {
+		return 0, err
+	}
+
+	// /snap/bin holds the snap commands
+	fi, err := os.Stat(filepath.Join(root, snapDir, "bin"))
+	if err == nil && fi.IsDir() && n > 0 {
+		n--
+	}
+	return n, nil
+}
+
+// countLinePrefix counts the lines of f starting with prefix
+func countLinePrefix(f, prefix string) (int, error) {
+	file, err := os.Open(f)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var n int
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), prefix) {
+			n++
+		}
+	}
+
+	return n, scanner.Err()
+}
+
+// formatPackages formats the counts as "1234 (pacman), 56 (flatpak)"
+func formatPackages(label string) FormatFunc {
+	return func(o *Options, v interface{}) ([]Line, error) {
+		counts := v.([]PackageCount)
+		if len(counts) == 0 {
+			return []Line{{Name: label, Text: "0"}}, nil
+		}
+
+		var sl []string
+		for _, c := range counts {
+			sl = append(sl, strconv.Itoa(c.Count)+" ("+c.Manager+")")
+		}
+		return []Line{{Name: label, Text: strings.Join(sl, ", ")}}, nil
+	}
+}
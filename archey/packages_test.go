@@ -0,0 +1,112 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	packagesRoot = "testdata/packages"
+	packagesHome = "testdata/packages/home/user"
+)
+
+func TestCountPackagesManagers(t *testing.T) {
+	tests := []struct {
+		name  string
+		count func(root, home string) (int, error)
+		want  int
+	}{
+		{"pacman", countPacman, 3},
+		{"dpkg", countDpkg, 2},
+		{"rpm", countRpm, 4},
+		{"apk", countApk, 2},
+		{"xbps", countXbps, 2},
+		{"portage", countPortage, 3},
+		{"nix", countNix, 3},
+		{"flatpak", countFlatpak, 3},
+		{"snap", countSnap, 2},
+	}
+
+	for _, tt := range tests {
+		n, err := tt.count(packagesRoot, packagesHome)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if n != tt.want {
+			t.Errorf("%s: got %d packages, want %d", tt.name, n, tt.want)
+		}
+	}
+}
+
+func TestCountPackages(t *testing.T) {
+	want := []PackageCount{
+		{"pacman", 3}, {"dpkg", 2}, {"rpm", 4}, {"apk", 2}, {"xbps", 2},
+		{"portage", 3}, {"nix", 3}, {"flatpak", 3}, {"snap", 2},
+	}
+	if got := CountPackages(packagesRoot, packagesHome, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// the user's nix profile and flatpaks are only found with a home
+	want = []PackageCount{{"dpkg", 2}, {"nix", 2}, {"flatpak", 2}}
+	got := CountPackages(packagesRoot, "",
+		[]string{"pacman", "RPM", "apk", "xbps", "portage", "snap"})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCountPackagesMissing(t *testing.T) {
+	if got := CountPackages("testdata/nonexistent", "", nil); len(got) != 0 {
+		t.Errorf("got %v, want no package managers", got)
+	}
+}
+
+func TestCollectPackagesHome(t *testing.T) {
+	o := New()
+	o.Sysroot = packagesRoot
+	o.NoPackageManagers = []string{"pacman", "dpkg", "rpm", "apk", "xbps", "portage", "snap"}
+
+	tests := []struct {
+		home string
+		want []PackageCount
+	}{
+		{"/home/user", []PackageCount{{"nix", 3}, {"flatpak", 3}}},
+		// not the packages in .local of the sysroot's root directory
+		{"", []PackageCount{{"nix", 2}, {"flatpak", 2}}},
+	}
+
+	for _, tt := range tests {
+		setenv(t, map[string]string{"HOME": tt.home})
+		got, err := collectPackages(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("HOME=%q: got %v, want %v", tt.home, got, tt.want)
+		}
+	}
+}
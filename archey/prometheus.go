@@ -0,0 +1,138 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// prefix of the names of the exported metrics
+const metricPrefix = "archey_"
+
+// metric is a gauge in the Prometheus text exposition format
+type metric struct {
+	name    string
+	help    string
+	samples []sample
+}
+
+type sample struct {
+	labels [][2]string
+	value  float64
+}
+
+// labelEscaper escapes the label values as the exposition format requires
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Prometheus returns the numeric values of si in the Prometheus text
+// exposition format: memory, swap and file system usage in bytes, uptime
+// in seconds and package counts, along with an info metric labeled with
+// the os, kernel and cpu. Fields which are hidden are left out.
+func (si SystemInfo) Prometheus() string {
+	var metrics []metric
+	gauge := func(name, help string, samples ...sample) {
+		if len(samples) > 0 {
+			metrics = append(metrics, metric{metricPrefix + name, help, samples})
+		}
+	}
+
+	if si.has("os", "kernel", "cpu") {
+		var labels [][2]string
+		if si.has("os") {
+			labels = append(labels, [2]string{"os", si.OS}, [2]string{"arch", si.Arch})
+		}
+		if si.has("kernel") {
+			labels = append(labels, [2]string{"kernel", si.Kernel})
+		}
+		if si.has("cpu") {
+			labels = append(labels, [2]string{"cpu", si.CPU})
+		}
+		gauge("info", "Operating system, kernel and cpu, always 1.", sample{labels, 1})
+	}
+
+	if si.has("uptime") {
+		gauge("uptime_seconds", "Time since the system booted.",
+			sample{nil, float64(si.Uptime)})
+	}
+
+	if si.Memory != nil {
+		gauge("memory_used_bytes", "Memory in use.", sample{nil, float64(si.Memory.Used)})
+		gauge("memory_total_bytes", "Total memory.", sample{nil, float64(si.Memory.Total)})
+	}
+
+	if si.Swap != nil {
+		gauge("swap_used_bytes", "Swap in use.", sample{nil, float64(si.Swap.Used)})
+		gauge("swap_total_bytes", "Total swap.", sample{nil, float64(si.Swap.Total)})
+	}
+
+	// the paths may repeat the root and home file systems,
+	// every path is exported once
+	var used, total []sample
+	seen := make(map[string]bool)
+	addFS := func(path string, u *Usage) {
+		if u == nil || seen[path] {
+			return
+		}
+		seen[path] = true
+		labels := [][2]string{{"path", path}}
+		used = append(used, sample{labels, float64(u.Used)})
+		total = append(total, sample{labels, float64(u.Total)})
+	}
+	addFS("/", si.Root)
+	addFS("/home", si.Home)
+	for _, p := range si.Paths {
+		if !p.TimedOut {
+			usage := p.Usage
+			addFS(p.Path, &usage)
+		}
+	}
+	gauge("filesystem_used_bytes", "Space in use on the file system the path is on.", used...)
+	gauge("filesystem_total_bytes", "Size of the file system the path is on.", total...)
+
+	var packages []sample
+	for _, p := range si.Packages {
+		packages = append(packages, sample{[][2]string{{"manager", p.Manager}}, float64(p.Count)})
+	}
+	gauge("packages", "Packages installed by the package manager.", packages...)
+
+	var b strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", m.name)
+		for _, s := range m.samples {
+			b.WriteString(m.name)
+			if len(s.labels) > 0 {
+				pairs := make([]string, len(s.labels))
+				for i, l := range s.labels {
+					pairs[i] = fmt.Sprintf(`%s="%s"`, l[0], labelEscaper.Replace(l[1]))
+				}
+				b.WriteString("{" + strings.Join(pairs, ",") + "}")
+			}
+			b.WriteString(" " + strconv.FormatFloat(s.value, 'f', -1, 64) + "\n")
+		}
+	}
+
+	return b.String()
+}
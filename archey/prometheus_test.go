@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPrometheus(t *testing.T) {
+	si := infoOf(t, map[string]interface{}{
+		"os":     osInfo{`Arch "Linux"`, "x86_64"},
+		"kernel": `6.9.1\arch`,
+		"cpu":    "AMD Ryzen 7\n5800X",
+		"uptime": uint64(123456),
+		"memory": &Usage{Used: 8 * mib, Total: 16 * mib},
+		"root":   &Usage{Used: 1 << 30, Total: 1 << 32},
+		"paths": []PathUsage{
+			{Path: "/", Usage: Usage{Used: 1, Total: 2}},
+			{Path: "/mnt/nfs", TimedOut: true},
+			{Path: "/data", Usage: Usage{Used: 3, Total: 4}},
+		},
+		"packages": []PackageCount{{"pacman", 3}, {"flatpak", 2}},
+	})
+	// the timed out fields aren't collected
+	si.TimedOut = []string{"swap", "home"}
+
+	got := si.Prometheus()
+	golden(t, "testdata/prometheus.golden", got)
+
+	// every sample follows the HELP and TYPE lines of its metric
+	name := regexp.MustCompile(`^archey_[a-z_]+$`)
+	var metric string
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		switch f := strings.Fields(line); {
+		case f[0] == "#" && f[1] == "HELP":
+			if !name.MatchString(f[2]) {
+				t.Errorf("invalid metric name in %q", line)
+			}
+			metric = f[2]
+		case f[0] == "#" && f[1] == "TYPE":
+			if f[2] != metric || f[3] != "gauge" {
+				t.Errorf("got %q, want the type of %s", line, metric)
+			}
+		default:
+			if !strings.HasPrefix(line, metric+" ") && !strings.HasPrefix(line, metric+"{") {
+				t.Errorf("got sample %q after the HELP of %s", line, metric)
+			}
+		}
+	}
+
+	for _, want := range []string{
+		`os="Arch \"Linux\""`,
+		`kernel="6.9.1\\arch"`,
+		`cpu="AMD Ryzen 7\n5800X"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing escaped label %s", want)
+		}
+	}
+
+	for _, hidden := range []string{"archey_swap", `path="/home"`, `path="/mnt/nfs"`} {
+		if strings.Contains(got, hidden) {
+			t.Errorf("got %s, want it left out", hidden)
+		}
+	}
+	if n := strings.Count(got, `archey_filesystem_used_bytes{path="/"}`); n != 1 {
+		t.Errorf("got the root file system %d times, want once", n)
+	}
+}
+
+func TestPrometheusHidden(t *testing.T) {
+	if got := (SystemInfo{}).Prometheus(); got != "" {
+		t.Errorf("got %q with every field hidden, want nothing", got)
+	}
+
+	// the info metric is labeled with the shown fields alone
+	got := infoOf(t, map[string]interface{}{"kernel": "6.9.1"}).Prometheus()
+	want := "# HELP archey_info Operating system, kernel and cpu, always 1.\n" +
+		"# TYPE archey_info gauge\n" +
+		"archey_info{kernel=\"6.9.1\"} 1\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,197 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Line is a single formatted line of the info column, e.g. Kernel: 4.9.6-1-ARCH
+type Line struct {
+	Name string
+	Text string
+}
+
+// Provider is implemented by every info field archey can display.
+// Providers are registered with Register and rendered in registration order.
+type Provider interface {
+	// Name returns the unique key of the field, e.g. "gtk2_theme".
+	// It is used to generate the --no-gtk2-theme flag
+	// and the show.no_gtk2_theme config key.
+	Name() string
+	// Label returns the name displayed in front of the separator
+	Label() string
+	// Collect gathers the raw value of the field
+	Collect(o *Options) (interface{}, error)
+	// Format turns the value returned by Collect into info lines
+	Format(o *Options, v interface{}) ([]Line, error)
+}
+
+var ErrDuplicateProvider = func(n string) error {
+	return fmt.Errorf("provider '%s' is already registered", n)
+}
+
+var ErrReservedProvider = func(n string) error {
+	return fmt.Errorf("provider name '%s' is reserved", n)
+}
+
+// names providers can't be registered under: the keys of the JSON output
+// which don't belong to a provider, as the values of providers registered
+// outside of archey are encoded under their name, and the pseudo-modules
+var reservedNames = map[string]bool{
+	"arch":          true,
+	"gtk2":          true,
+	"gtk3":          true,
+	"timed_out":     true,
+	moduleSeparator: true,
+	moduleBlank:     true,
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Provider
+)
+
+// Register adds p to the list of available fields.
+// It panics if a provider with the same name is already registered
+// or the name is reserved, so it's meant to be called from init functions.
+func Register(p Provider) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if reservedNames[p.Name()] {
+		panic(ErrReservedProvider(p.Name()))
+	}
+
+	for _, r := range registry {
+		if r.Name() == p.Name() {
+			panic(ErrDuplicateProvider(p.Name()))
+		}
+	}
+
+	registry = append(registry, p)
+}
+
+// Providers returns all registered providers in registration order
+func Providers() []Provider {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	providers := make([]Provider, len(registry))
+	copy(providers, registry)
+	return providers
+}
+
+// Lookup returns the provider registered under name
+func Lookup(name string) (Provider, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, p := range registry {
+		if p.Name() == name {
+			return p, true
+		}
+	}
+
+	return nil, false
+}
+
+// CollectFunc gathers the raw value of a field
+type CollectFunc func(o *Options) (interface{}, error)
+
+// FormatFunc turns a collected value into info lines
+type FormatFunc func(o *Options, v interface{}) ([]Line, error)
+
+type provider struct {
+	name    string
+	label   string
+	collect CollectFunc
+	format  FormatFunc
+	// store sets the typed SystemInfo field of built-in providers
+	store func(si *SystemInfo, v interface{})
+	// selfTimed providers apply their timeout themselves
+	selfTimed bool
+	// dynamic providers are collected again by Refresh
+	dynamic bool
+}
+
+// NewProvider returns a Provider built from the given functions.
+// If format is nil the value is printed with fmt.Sprint on a single line.
+func NewProvider(name, label string, collect CollectFunc, format FormatFunc) Provider {
+	return &provider{
+		name:    name,
+		label:   label,
+		collect: collect,
+		format:  format,
+	}
+}
+
+// builtin returns a provider which stores its value in SystemInfo.
+// format is given the label so the lines are named after the provider.
+func builtin(name, label string, collect CollectFunc, format func(label string) FormatFunc,
+	store func(si *SystemInfo, v interface{})) Provider {
+	p := &provider{
+		name:    name,
+		label:   label,
+		collect: collect,
+		store:   store,
+	}
+	if format != nil {
+		p.format = format(label)
+	}
+	return p
+}
+
+// unlabeled adapts format, which names the lines after the values
+// instead of the provider, to builtin
+func unlabeled(format FormatFunc) func(label string) FormatFunc {
+	return func(string) FormatFunc { return format }
+}
+
+// dynamic marks the builtin provider p as one whose value changes
+// while archey-go runs, so it's collected again by Refresh
+func dynamic(p Provider) Provider {
+	p.(*provider).dynamic = true
+	return p
+}
+
+// isDynamic reports whether the value of p changes while archey-go runs
+func isDynamic(p Provider) bool {
+	bp, ok := p.(*provider)
+	return ok && bp.dynamic
+}
+
+func (p *provider) Name() string  { return p.name }
+func (p *provider) Label() string { return p.label }
+
+func (p *provider) Collect(o *Options) (interface{}, error) {
+	return p.collect(o)
+}
+
+func (p *provider) Format(o *Options, v interface{}) ([]Line, error) {
+	if p.format == nil {
+		return []Line{{Name: p.label, Text: fmt.Sprint(v)}}, nil
+	}
+	return p.format(o, v)
+}
@@ -0,0 +1,88 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import "testing"
+
+func TestRegisterRejects(t *testing.T) {
+	for _, name := range []string{"arch", "timed_out", "gtk2", "separator", "blank", "shell", "os"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("registering '%s' didn't panic", name)
+				}
+			}()
+			Register(NewProvider(name, "Test", nil, nil))
+		}()
+	}
+
+	// the registered ones are left alone
+	if p, ok := Lookup("shell"); !ok || p.Label() != "Shell" {
+		t.Errorf("got %v for shell, want the built-in provider", p)
+	}
+}
+
+// Every key of the JSON output must be either a provider name or reserved,
+// so that the values of providers registered outside of archey, which are
+// encoded under their name, can't collide with them.
+func TestJSONKeysReserved(t *testing.T) {
+	si := SystemInfo{values: make(map[string]interface{}), TimedOut: []string{"cpu"}}
+	for _, p := range Providers() {
+		si.values[p.Name()] = nil
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range si.jsonFields() {
+		if seen[f.key] {
+			t.Errorf("duplicate key '%s'", f.key)
+		}
+		seen[f.key] = true
+
+		if _, ok := Lookup(f.key); !ok && !reservedNames[f.key] {
+			t.Errorf("key '%s' is neither a provider name nor reserved", f.key)
+		}
+	}
+}
+
+func TestBuiltinLabel(t *testing.T) {
+	o := New()
+	tests := []struct {
+		p    Provider
+		v    interface{}
+		want string
+	}{
+		{builtin("os", "System", nil, formatOS, nil), osInfo{name: "Arch Linux", arch: "x86_64"}, "System"},
+		{builtin("packages", "Installed", nil, formatPackages, nil), []PackageCount{}, "Installed"},
+		{builtin("root", "Disk", nil, formatFS("/root"), nil), &Usage{}, "Disk"},
+	}
+
+	for _, tt := range tests {
+		lines, err := tt.p.Format(o, tt.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lines) != 1 || lines[0].Name != tt.want {
+			t.Errorf("%s: got %+v, want a line named %q", tt.p.Name(), lines, tt.want)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// The readers below only go as far as counting the packages of
+// rpm's database so that no SQLite or Berkeley DB library is needed.
+// Pages of the SQLite database which are still in its write-ahead
+// log are read from there, as long as they were committed.
+
+var ErrInvalidDB = func(f, reason string) error {
+	return fmt.Errorf("invalid database '%s': %s", f, reason)
+}
+
+var errNoTable = errors.New("table not found")
+
+// SQLite file format constants
+const (
+	sqliteMagic        = "SQLite format 3\x00"
+	sqliteHeaderSize   = 100
+	sqliteInteriorPage = 0x05
+	sqliteLeafPage     = 0x0d
+	sqliteMaxDepth     = 32
+	sqliteMaxPages     = 1 << 20
+	sqliteMinUsable    = 480
+	sqliteMaxPayload   = 1<<31 - 1
+)
+
+// SQLite write-ahead log constants, the magic of a log
+// with big-endian checksums has the lowest bit set
+const (
+	walMagic       = 0x377f0682
+	walHeaderSize  = 32
+	walFrameHeader = 24
+)
+
+type sqliteDB struct {
+	file     *os.File
+	name     string
+	pageSize int
+	usable   int
+	// wal holds the offsets in the write-ahead log of
+	// the latest committed version of the pages in it
+	wal    *os.File
+	frames map[uint32]int64
+}
+
+// sqliteCountRows counts the rows of table in the SQLite database f
+func sqliteCountRows(f, table string) (int, error) {
+	file, err := os.Open(f)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	header := make([]byte, sqliteHeaderSize)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return 0, err
+	}
+
+	if string(header[:16]) != sqliteMagic {
+		return 0, ErrInvalidDB(f, "not an SQLite database")
+	}
+
+	db := &sqliteDB{file: file, name: f}
+	db.pageSize = int(binary.BigEndian.Uint16(header[16:18]))
+	if db.pageSize == 1 {
+		db.pageSize = 65536
+	}
+	if db.pageSize < 512 {
+		return 0, ErrInvalidDB(f, "invalid page size")
+	}
+	db.usable = db.pageSize - int(header[20])
+	if db.usable < sqliteMinUsable {
+		return 0, ErrInvalidDB(f, "invalid reserved space")
+	}
+
+	if wal, err := os.Open(f + "-wal"); err == nil {
+		defer wal.Close()
+		if err := db.readWAL(wal); err != nil {
+			return 0, err
+		}
+	}
+
+	rootPage, err := db.tableRoot(table)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	err = db.scan(rootPage, func(page []byte, cell int) error {
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// page reads the nth page, pages are numbered from 1
+func (db *sqliteDB) page(n uint32) ([]byte, error) {
+	if n == 0 {
+		return nil, ErrInvalidDB(db.name, "invalid page number")
+	}
+
+	page := make([]byte, db.pageSize)
+	if off, ok := db.frames[n]; ok {
+		if _, err := db.wal.ReadAt(page, off); err != nil {
+			return nil, err
+		}
+		return page, nil
+	}
+
+	if _, err := db.file.ReadAt(page, int64(n-1)*int64(db.pageSize)); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// readWAL finds the pages of the write-ahead log wal which were
+// committed. The log ends at the first frame whose salt or checksum
+// doesn't match, as frames left over from before the last checkpoint
+// and frames of an interrupted write don't.
+func (db *sqliteDB) readWAL(wal *os.File) error {
+	header := make([]byte, walHeaderSize)
+	if _, err := wal.ReadAt(header, 0); err != nil {
+		// an empty log has no frames
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	magic := binary.BigEndian.Uint32(header)
+	if magic&^1 != walMagic {
+		return ErrInvalidDB(db.name+"-wal", "not an SQLite write-ahead log")
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if magic&1 == 1 {
+		order = binary.BigEndian
+	}
+
+	if int(binary.BigEndian.Uint32(header[8:])) != db.pageSize {
+		return ErrInvalidDB(db.name+"-wal", "page size doesn't match the database")
+	}
+
+	s0, s1 := walChecksum(order, header[:24], 0, 0)
+	if s0 != binary.BigEndian.Uint32(header[24:]) || s1 != binary.BigEndian.Uint32(header[28:]) {
+		return nil
+	}
+	salt := header[16:24]
+
+	db.wal = wal
+	db.frames = make(map[uint32]int64)
+	pending := make(map[uint32]int64)
+
+	frame := make([]byte, walFrameHeader+db.pageSize)
+	for off := int64(walHeaderSize); ; off += int64(len(frame)) {
+		if _, err := wal.ReadAt(frame, off); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if !bytes.Equal(frame[8:16], salt) {
+			return nil
+		}
+		s0, s1 = walChecksum(order, frame[:8], s0, s1)
+		s0, s1 = walChecksum(order, frame[walFrameHeader:], s0, s1)
+		if s0 != binary.BigEndian.Uint32(frame[16:]) || s1 != binary.BigEndian.Uint32(frame[20:]) {
+			return nil
+		}
+
+		pending[binary.BigEndian.Uint32(frame)] = off + walFrameHeader
+
+		// the frames of a transaction count once its last one is written
+		if binary.BigEndian.Uint32(frame[4:]) != 0 {
+			for n, o := range pending {
+				db.frames[n] = o
+			}
+			pending = make(map[uint32]int64)
+		}
+	}
+}
+
+// walChecksum continues the checksum s0, s1 of a write-ahead log over b
+func walChecksum(order binary.ByteOrder, b []byte, s0, s1 uint32) (uint32, uint32) {
+	for i := 0; i+8 <= len(b); i += 8 {
+		s0 += order.Uint32(b[i:]) + s1
+		s1 += order.Uint32(b[i+4:]) + s0
+	}
+	return s0, s1
+}
+
+// scan calls leaf with the offset of every cell
+// of the table b-tree whose root page is n
+func (db *sqliteDB) scan(n uint32, leaf func(page []byte, cell int) error) error {
+	return db.walk(n, 0, make(map[uint32]bool), leaf)
+}
+
+// walk scans the b-tree whose root page is n at depth, visited holding the
+// pages read so far. A page of a b-tree has a single parent, so a corrupt
+// database whose pages repeat, which would have the walk read pages over and
+// over, is rejected.
+func (db *sqliteDB) walk(n uint32, depth int, visited map[uint32]bool, leaf func(page []byte, cell int) error) error {
+	switch {
+	case depth > sqliteMaxDepth:
+		return ErrInvalidDB(db.name, "b-tree too deep")
+	case visited[n]:
+		return ErrInvalidDB(db.name, "page repeated in the b-tree")
+	case len(visited) >= sqliteMaxPages:
+		return ErrInvalidDB(db.name, "too many pages")
+	}
+	visited[n] = true
+
+	page, err := db.page(n)
+	if err != nil {
+		return err
+	}
+
+	// the first page starts with the database header
+	hdr := 0
+	if n == 1 {
+		hdr = sqliteHeaderSize
+	}
+
+	if len(page) < hdr+12 {
+		return ErrInvalidDB(db.name, "short page")
+	}
+
+	cells := int(binary.BigEndian.Uint16(page[hdr+3 : hdr+5]))
+
+	switch page[hdr] {
+	case sqliteLeafPage:
+		ptrs := hdr + 8
+		if ptrs+2*cells > len(page) {
+			return ErrInvalidDB(db.name, "too many cells")
+		}
+		for i := 0; i < cells; i++ {
+			cell := int(binary.BigEndian.Uint16(page[ptrs+2*i:]))
+			if err := leaf(page, cell); err != nil {
+				return err
+			}
+		}
+	case sqliteInteriorPage:
+		ptrs := hdr + 12
+		if ptrs+2*cells > len(page) {
+			return ErrInvalidDB(db.name, "too many cells")
+		}
+		for i := 0; i < cells; i++ {
+			cell := int(binary.BigEndian.Uint16(page[ptrs+2*i:]))
+			if cell+4 > len(page) {
+				return ErrInvalidDB(db.name, "invalid cell")
+			}
+			child := binary.BigEndian.Uint32(page[cell:])
+			if err := db.walk(child, depth+1, visited, leaf); err != nil {
+				return err
+			}
+		}
+		right := binary.BigEndian.Uint32(page[hdr+8:])
+		return db.walk(right, depth+1, visited, leaf)
+	default:
+		return ErrInvalidDB(db.name, "not a table b-tree page")
+	}
+
+	return nil
+}
+
+// tableRoot returns the root page of table as read from sqlite_master
+func (db *sqliteDB) tableRoot(table string) (uint32, error) {
+	var root uint32
+	found := errors.New("found")
+
+	err := db.scan(1, func(page []byte, cell int) error {
+		cols, err := db.record(page, cell, 4)
+		if err != nil {
+			return err
+		}
+
+		// type, name, tbl_name, rootpage
+		if string(cols[0]) == "table" && strings.EqualFold(string(cols[1]), table) {
+			var n uint64
+			for _, b := range cols[3] {
+				n = n<<8 | uint64(b)
+			}
+			root = uint32(n)
+			return found
+		}
+		return nil
+	})
+
+	if err == found {
+		return root, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 0, errNoTable
+}
+
+// record returns the raw values of the first n columns of the
+// table leaf cell at offset cell, which have to be stored locally
+func (db *sqliteDB) record(page []byte, cell, n int) ([][]byte, error) {
+	if cell >= len(page) {
+		return nil, ErrInvalidDB(db.name, "invalid cell")
+	}
+	b := page[cell:]
+
+	payload, l := sqliteVarint(b)
+	b = b[l:]
+	_, l = sqliteVarint(b) // rowid
+	b = b[l:]
+
+	if payload > sqliteMaxPayload {
+		return nil, ErrInvalidDB(db.name, "invalid payload size")
+	}
+	local := db.localPayload(int(payload))
+	if local > len(b) {
+		return nil, ErrInvalidDB(db.name, "invalid payload size")
+	}
+	b = b[:local]
+
+	hdrSize, l := sqliteVarint(b)
+	if l == 0 || hdrSize < uint64(l) || hdrSize > uint64(len(b)) {
+		return nil, ErrInvalidDB(db.name, "invalid record header")
+	}
+	hdr := b[l:hdrSize]
+	body := b[hdrSize:]
+
+	var cols [][]byte
+	for len(cols) < n && len(hdr) > 0 {
+		serial, l := sqliteVarint(hdr)
+		hdr = hdr[l:]
+
+		size := sqliteSerialSize(serial)
+		if size > uint64(len(body)) {
+			return nil, ErrInvalidDB(db.name, "record overflows page")
+		}
+		cols = append(cols, body[:size])
+		body = body[size:]
+	}
+
+	if len(cols) < n {
+		return nil, ErrInvalidDB(db.name, "short record")
+	}
+	return cols, nil
+}
+
+// localPayload returns how many bytes of a table leaf
+// cell payload of size p are stored on the page itself
+func (db *sqliteDB) localPayload(p int) int {
+	u := db.usable
+	x := u - 35
+	if p <= x {
+		return p
+	}
+
+	m := (u-12)*32/255 - 23
+	k := m + (p-m)%(u-4)
+	if k <= x {
+		return k
+	}
+	return m
+}
+
+// sqliteVarint decodes a big-endian SQLite varint
+// and returns its value and length
+func sqliteVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < len(b) && i < 9; i++ {
+		if i == 8 {
+			return v<<8 | uint64(b[i]), 9
+		}
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return v, len(b)
+}
+
+// sqliteSerialSize returns the size of a value of the serial type t
+func sqliteSerialSize(t uint64) uint64 {
+	switch {
+	case t >= 12:
+		return (t - 12) / 2
+	case t == 5:
+		return 6
+	case t == 6, t == 7:
+		return 8
+	case t >= 1 && t <= 4:
+		return t
+	}
+	return 0
+}
+
+// Berkeley DB hash file constants
+const (
+	bdbHashMagic  = 0x061561
+	bdbMetaSize   = 72
+	bdbPageHdr    = 26
+	bdbHashPage   = 13
+	bdbHashPageV2 = 2 // P_HASH_UNSORTED
+)
+
+// bdbCountRecords counts the key/data pairs of the Berkeley DB hash database f
+func bdbCountRecords(f string) (int, error) {
+	file, err := os.Open(f)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	meta := make([]byte, bdbMetaSize)
+	if _, err := file.ReadAt(meta, 0); err != nil {
+		return 0, err
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case binary.LittleEndian.Uint32(meta[12:]) == bdbHashMagic:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(meta[12:]) == bdbHashMagic:
+		order = binary.BigEndian
+	default:
+		return 0, ErrInvalidDB(f, "not a Berkeley DB hash database")
+	}
+
+	pageSize := int64(order.Uint32(meta[20:]))
+	lastPage := int64(order.Uint32(meta[32:]))
+	if pageSize < bdbPageHdr {
+		return 0, ErrInvalidDB(f, "invalid page size")
+	}
+
+	var n int
+	hdr := make([]byte, bdbPageHdr)
+	for p := int64(1); p <= lastPage; p++ {
+		if _, err := file.ReadAt(hdr, p*pageSize); err != nil {
+			return 0, err
+		}
+
+		if t := hdr[25]; t != bdbHashPage && t != bdbHashPageV2 {
+			continue
+		}
+
+		// entries holds both the keys and the data items
+		n += int(order.Uint16(hdr[20:])) / 2
+	}
+
+	return n, nil
+}
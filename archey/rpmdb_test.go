@@ -0,0 +1,239 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSqliteCountRows(t *testing.T) {
+	tests := []struct {
+		file  string
+		table string
+		want  int
+	}{
+		{"testdata/packages/var/lib/rpm/rpmdb.sqlite", "Packages", 4},
+		{"testdata/packages/var/lib/rpm/rpmdb.sqlite", "Name", 0},
+		{"testdata/rpmdb/large.sqlite", "Packages", 300},
+		// 5 rows in the database and 3 more in its write-ahead log
+		{"testdata/rpmdb/wal.sqlite", "Packages", 8},
+	}
+
+	for _, tt := range tests {
+		n, err := sqliteCountRows(tt.file, tt.table)
+		if err != nil {
+			t.Errorf("%s: %v", tt.file, err)
+			continue
+		}
+		if n != tt.want {
+			t.Errorf("%s: got %d rows of %s, want %d", tt.file, n, tt.table, tt.want)
+		}
+	}
+
+	if _, err := sqliteCountRows("testdata/rpmdb/large.sqlite", "Missing"); err != errNoTable {
+		t.Errorf("got %v for a missing table, want %v", err, errNoTable)
+	}
+}
+
+func TestSqliteCountRowsCorrupt(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/packages/var/lib/rpm/rpmdb.sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first cell of the sqlite_master page
+	cell := int(binary.BigEndian.Uint16(b[sqliteHeaderSize+8:]))
+	// payload size and rowid are single byte varints
+	hdrSize := cell + 2
+
+	corrupt := func(fn func(b []byte) []byte) []byte {
+		c := append([]byte(nil), b...)
+		return fn(c)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not sqlite", []byte("SQLite format 2\x00" + string(make([]byte, 100)))},
+		{"truncated", b[:sqliteHeaderSize+20]},
+		{"header size below its own length", corrupt(func(c []byte) []byte {
+			c[hdrSize] = 0
+			return c
+		})},
+		{"header size past the payload", corrupt(func(c []byte) []byte {
+			c[hdrSize] = 0x7f
+			return c
+		})},
+		{"huge serial type", corrupt(func(c []byte) []byte {
+			copy(c[hdrSize+1:], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+			return c
+		})},
+		{"huge payload", corrupt(func(c []byte) []byte {
+			copy(c[cell:], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+			return c
+		})},
+		{"cell past the page", corrupt(func(c []byte) []byte {
+			binary.BigEndian.PutUint16(c[sqliteHeaderSize+8:], 0xffff)
+			return c
+		})},
+		{"bad page type", corrupt(func(c []byte) []byte {
+			c[sqliteHeaderSize] = 0x02
+			return c
+		})},
+	}
+
+	dir, err := ioutil.TempDir("", "archey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tt := range tests {
+		f := filepath.Join(dir, "rpmdb.sqlite")
+		if err := ioutil.WriteFile(f, tt.data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if n, err := sqliteCountRows(f, "Packages"); err == nil {
+			t.Errorf("%s: got %d rows, want an error", tt.name, n)
+		}
+	}
+}
+
+func TestSqliteCountRowsTornWAL(t *testing.T) {
+	db, err := ioutil.ReadFile("testdata/rpmdb/wal.sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wal, err := ioutil.ReadFile("testdata/rpmdb/wal.sqlite-wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the last frame commits the rows in the log
+	wal[len(wal)-1] ^= 0xff
+
+	dir, err := ioutil.TempDir("", "archey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	f := filepath.Join(dir, "rpmdb.sqlite")
+	if err := ioutil.WriteFile(f, db, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(f+"-wal", wal, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := sqliteCountRows(f, "Packages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Errorf("got %d rows, want the 5 committed to the database", n)
+	}
+}
+
+func TestBdbCountRecords(t *testing.T) {
+	// pairs of the two hash pages, the third page isn't one
+	n, err := bdbCountRecords("testdata/rpmdb/Packages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Errorf("got %d records, want 5", n)
+	}
+
+	if _, err := bdbCountRecords("testdata/rpmdb/large.sqlite"); err == nil {
+		t.Error("got no error for an SQLite database")
+	}
+}
+
+func TestSqliteCountRowsRepeatedPages(t *testing.T) {
+	const f = "testdata/rpmdb/large.sqlite"
+	b, err := ioutil.ReadFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	db := &sqliteDB{file: file, name: f, pageSize: int(binary.BigEndian.Uint16(b[16:]))}
+	db.usable = db.pageSize - int(b[20])
+	root, err := db.tableRoot("Packages")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page := int(root-1) * db.pageSize
+	if b[page] != sqliteInteriorPage {
+		t.Fatalf("root page %d of Packages isn't an interior page", root)
+	}
+
+	// repoint the children and the right-most pointer of the root
+	repoint := func(child func(first uint32) uint32) []byte {
+		c := append([]byte(nil), b...)
+		cells := int(binary.BigEndian.Uint16(c[page+3:]))
+		first := binary.BigEndian.Uint32(c[page+int(binary.BigEndian.Uint16(c[page+12:])):])
+		for i := 0; i < cells; i++ {
+			cell := page + int(binary.BigEndian.Uint16(c[page+12+2*i:]))
+			binary.BigEndian.PutUint32(c[cell:], child(first))
+		}
+		binary.BigEndian.PutUint32(c[page+8:], child(first))
+		return c
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		// without tracking the pages, every level of the loop
+		// would multiply the pages read by the number of cells
+		{"root is its own child", repoint(func(uint32) uint32 { return root })},
+		{"children repeated", repoint(func(first uint32) uint32 { return first })},
+	}
+
+	dir, err := ioutil.TempDir("", "archey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tt := range tests {
+		f := filepath.Join(dir, "rpmdb.sqlite")
+		if err := ioutil.WriteFile(f, tt.data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if n, err := sqliteCountRows(f, "Packages"); err == nil {
+			t.Errorf("%s: got %d rows, want an error", tt.name, n)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const etcPasswd = "/etc/passwd"
+
+// Shell is the shell archey-go runs in
+type Shell struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	// Login is the login shell of the user as set in /etc/passwd
+	Login string `json:"login"`
+}
+
+// shells by process name
+var shells = map[string]bool{
+	"bash":   true,
+	"zsh":    true,
+	"fish":   true,
+	"sh":     true,
+	"dash":   true,
+	"ksh":    true,
+	"mksh":   true,
+	"tcsh":   true,
+	"csh":    true,
+	"nu":     true,
+	"elvish": true,
+	"xonsh":  true,
+	"ion":    true,
+}
+
+// variables holding the version of the shells. Some shells export theirs,
+// e.g. fish and nu, while bash and zsh keep them as shell variables which
+// are only seen when the user exports them.
+var shellVersionEnv = map[string]string{
+	"bash":  "BASH_VERSION",
+	"zsh":   "ZSH_VERSION",
+	"fish":  "FISH_VERSION",
+	"ksh":   "KSH_VERSION",
+	"mksh":  "KSH_VERSION",
+	"nu":    "NU_VERSION",
+	"xonsh": "XONSH_VERSION",
+}
+
+// shells which print their version when run with --version, which is
+// done when the version isn't found in the environment
+var shellVersionFlag = map[string]bool{
+	"bash":  true,
+	"zsh":   true,
+	"fish":  true,
+	"tcsh":  true,
+	"nu":    true,
+	"xonsh": true,
+}
+
+var shellVersion = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// getShell returns the shell running archey-go, found by walking up the parents
+// of the process pid in the proc file system mounted at dir, falling back to
+// SHELL and then to the login shell read from passwd
+func getShell(dir, passwd string, pid, uid int, getenv func(string) string) *Shell {
+	sh := &Shell{Login: loginShell(passwd, uid)}
+
+	for _, p := range procAncestors(dir, pid) {
+		if shells[p.comm] {
+			sh.Name = p.comm
+			sh.Path, _ = os.Readlink(filepath.Join(dir, strconv.Itoa(p.pid), "exe"))
+			break
+		}
+	}
+
+	if sh.Name == "" {
+		sh.Path = getenv("SHELL")
+		if sh.Path == "" {
+			sh.Path = sh.Login
+		}
+		if sh.Path != "" {
+			sh.Name = filepath.Base(sh.Path)
+		}
+	}
+	if sh.Path == "" {
+		sh.Path = sh.Name
+	}
+
+	return sh
+}
+
+// loginShell returns the shell of the user uid from the passwd file
+func loginShell(passwd string, uid int) string {
+	f, err := os.Open(passwd)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	id := strconv.Itoa(uid)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// name:password:uid:gid:gecos:home:shell
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) == 7 && fields[2] == id {
+			return fields[6]
+		}
+	}
+
+	return ""
+}
+
+// envShellVersion returns the version of the shell name from its variable,
+// getenv holding what the shell exported
+func envShellVersion(name string, getenv func(string) string) string {
+	if env := shellVersionEnv[name]; env != "" {
+		return shellVersion.FindString(getenv(env))
+	}
+	return ""
+}
+
+// runShellVersion returns the version printed by the shell
+// at path when run with --version
+func runShellVersion(ctx context.Context, path string) string {
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return shellVersion.FindString(string(out))
+}
+
+// GetShell returns the shell archey-go runs in
+func GetShell() *Shell {
+	sh := getShell(procDir, etcPasswd, os.Getpid(), os.Getuid(), os.Getenv)
+	sh.Version = envShellVersion(sh.Name, os.Getenv)
+	if sh.Version == "" && shellVersionFlag[sh.Name] {
+		sh.Version = runShellVersion(context.Background(), sh.Path)
+	}
+	return sh
+}
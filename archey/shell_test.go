@@ -0,0 +1,63 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import "testing"
+
+func TestGetShell(t *testing.T) {
+	const passwd = "testdata/shell/passwd"
+
+	tests := []struct {
+		name string
+		pid  int
+		uid  int
+		env  map[string]string
+		want Shell
+	}{
+		{"nearest shell of the process tree", 14, 1000,
+			map[string]string{"SHELL": "/usr/bin/fish"},
+			Shell{Name: "bash", Path: "bash", Login: "/usr/bin/zsh"}},
+		{"exported version", 32, 1000,
+			map[string]string{"ZSH_VERSION": "5.9"},
+			Shell{Name: "zsh", Path: "zsh", Version: "5.9", Login: "/usr/bin/zsh"}},
+		{"version of another shell", 14, 1000,
+			map[string]string{"FISH_VERSION": "3.7.1"},
+			Shell{Name: "bash", Path: "bash", Login: "/usr/bin/zsh"}},
+		{"SHELL without a process tree", 999, 0,
+			map[string]string{"SHELL": "/usr/bin/fish", "FISH_VERSION": "3.7.1"},
+			Shell{Name: "fish", Path: "/usr/bin/fish", Version: "3.7.1", Login: "/bin/bash"}},
+		{"login shell", 999, 1000, nil,
+			Shell{Name: "zsh", Path: "/usr/bin/zsh", Login: "/usr/bin/zsh"}},
+		{"unknown user", 999, 1001, nil,
+			Shell{}},
+	}
+
+	for _, tt := range tests {
+		getenv := func(k string) string { return tt.env[k] }
+		got := getShell(procFixture, passwd, tt.pid, tt.uid, getenv)
+		got.Version = envShellVersion(got.Name, getenv)
+		if *got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+}
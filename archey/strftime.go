@@ -0,0 +1,126 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// strftime formats t according to the strftime format f.
+// Unknown conversion specifications are left as they are.
+func strftime(t time.Time, f string) string {
+	var buf strings.Builder
+
+	for i := 0; i < len(f); i++ {
+		if f[i] != '%' || i == len(f)-1 {
+			buf.WriteByte(f[i])
+			continue
+		}
+
+		i++
+		switch f[i] {
+		case 'A':
+			buf.WriteString(t.Format("Monday"))
+		case 'a':
+			buf.WriteString(t.Format("Mon"))
+		case 'B':
+			buf.WriteString(t.Format("January"))
+		case 'b', 'h':
+			buf.WriteString(t.Format("Jan"))
+		case 'C':
+			fmt.Fprintf(&buf, "%02d", t.Year()/100)
+		case 'D':
+			buf.WriteString(t.Format("01/02/06"))
+		case 'd':
+			buf.WriteString(t.Format("02"))
+		case 'e':
+			buf.WriteString(t.Format("_2"))
+		case 'f':
+			fmt.Fprintf(&buf, "%06d", t.Nanosecond()/1000)
+		case 'F':
+			buf.WriteString(t.Format("2006-01-02"))
+		case 'H':
+			buf.WriteString(t.Format("15"))
+		case 'I':
+			buf.WriteString(t.Format("03"))
+		case 'j':
+			fmt.Fprintf(&buf, "%03d", t.YearDay())
+		case 'k':
+			fmt.Fprintf(&buf, "%2d", t.Hour())
+		case 'L':
+			fmt.Fprintf(&buf, "%03d", t.Nanosecond()/1000000)
+		case 'l':
+			h := t.Hour() % 12
+			if h == 0 {
+				h = 12
+			}
+			fmt.Fprintf(&buf, "%2d", h)
+		case 'M':
+			buf.WriteString(t.Format("04"))
+		case 'm':
+			buf.WriteString(t.Format("01"))
+		case 'N':
+			fmt.Fprintf(&buf, "%09d", t.Nanosecond())
+		case 'n':
+			buf.WriteByte('\n')
+		case 'P':
+			buf.WriteString(t.Format("pm"))
+		case 'p':
+			buf.WriteString(t.Format("PM"))
+		case 'R':
+			buf.WriteString(t.Format("15:04"))
+		case 'r':
+			buf.WriteString(t.Format("03:04:05 PM"))
+		case 'S':
+			buf.WriteString(t.Format("05"))
+		case 's':
+			buf.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'T':
+			buf.WriteString(t.Format("15:04:05"))
+		case 't':
+			buf.WriteByte('\t')
+		case 'v':
+			buf.WriteString(t.Format("_2-Jan-2006"))
+		case 'w':
+			buf.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'Y':
+			buf.WriteString(t.Format("2006"))
+		case 'y':
+			buf.WriteString(t.Format("06"))
+		case 'Z':
+			buf.WriteString(t.Format("MST"))
+		case 'z':
+			buf.WriteString(t.Format("-0700"))
+		case '%':
+			buf.WriteByte('%')
+		default:
+			buf.WriteByte('%')
+			buf.WriteByte(f[i])
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,132 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"os"
+	"strings"
+)
+
+// terminal emulators by process name, names longer
+// than 15 characters are truncated the same way comm is
+var terminals = map[string]string{
+	"alacritty":       "Alacritty",
+	"kitty":           "kitty",
+	"foot":            "foot",
+	"footclient":      "foot",
+	"wezterm-gui":     "WezTerm",
+	"wezterm":         "WezTerm",
+	"gnome-terminal-": "GNOME Terminal",
+	"gnome-terminal":  "GNOME Terminal",
+	"kgx":             "GNOME Console",
+	"konsole":         "Konsole",
+	"yakuake":         "Yakuake",
+	"xterm":           "XTerm",
+	"uxterm":          "XTerm",
+	"urxvt":           "URxvt",
+	"urxvtd":          "URxvt",
+	"rxvt":            "rxvt",
+	"st":              "st",
+	"terminator":      "Terminator",
+	"tilix":           "Tilix",
+	"xfce4-terminal":  "Xfce Terminal",
+	"mate-terminal":   "MATE Terminal",
+	"lxterminal":      "LXTerminal",
+	"qterminal":       "QTerminal",
+	"terminology":     "Terminology",
+	"guake":           "Guake",
+	"tilda":           "Tilda",
+	"sakura":          "Sakura",
+	"ghostty":         "Ghostty",
+	"contour":         "Contour",
+	"rio":             "Rio",
+	"cool-retro-term": "cool-retro-term",
+	"deepin-terminal": "Deepin Terminal",
+	"io.elementary.t": "elementary Terminal",
+	"cosmic-term":     "COSMIC Terminal",
+	"code":            "VS Code",
+	"vscode":          "VS Code",
+	"iterm.app":       "iTerm2",
+	"apple_terminal":  "Terminal.app",
+}
+
+// processes between the terminal emulator and archey-go
+// which are skipped while walking up the process tree
+var terminalSkip = map[string]bool{
+	"archey-go": true,
+	"go":        true,
+	"sudo":      true,
+	"su":        true,
+	"doas":      true,
+	"tmux":      true,
+	"screen":    true,
+	"login":     true,
+	"bash":      true,
+	"zsh":       true,
+	"fish":      true,
+	"sh":        true,
+	"dash":      true,
+	"ksh":       true,
+	"mksh":      true,
+	"tcsh":      true,
+	"csh":       true,
+	"nu":        true,
+	"elvish":    true,
+	"xonsh":     true,
+	"ion":       true,
+}
+
+// getTerminal returns the name of the terminal emulator archey-go runs in,
+// found by walking up the parents of the process pid in the proc file
+// system mounted at dir. It falls back to TERM_PROGRAM and then TERM.
+func getTerminal(dir string, pid int, getenv func(string) string) string {
+	for _, p := range procAncestors(dir, pid) {
+		comm := strings.ToLower(p.comm)
+		if name, ok := terminals[comm]; ok {
+			return name
+		}
+
+		// tmux names its server "tmux: server"
+		if terminalSkip[comm] || strings.HasPrefix(comm, "tmux") {
+			continue
+		}
+
+		// the first process that isn't skipped is as far as it goes,
+		// e.g. sshd for remote sessions
+		break
+	}
+
+	if tp := getenv("TERM_PROGRAM"); tp != "" {
+		if name, ok := terminals[strings.ToLower(tp)]; ok {
+			return name
+		}
+		return tp
+	}
+
+	return getenv("TERM")
+}
+
+// GetTerminal returns the name of the terminal emulator archey-go runs in
+func GetTerminal() string {
+	return getTerminal(procDir, os.Getpid(), os.Getenv)
+}
@@ -0,0 +1,75 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import "testing"
+
+// procFixture holds the process trees of the terminal tests,
+// archey-go runs as the last process of each one
+const procFixture = "testdata/proc"
+
+func TestGetTerminal(t *testing.T) {
+	tests := []struct {
+		name string
+		pid  int
+		env  map[string]string
+		want string
+	}{
+		{"tmux server is detached", 32,
+			map[string]string{"TERM_PROGRAM": "tmux", "TERM": "tmux-256color"}, "tmux"},
+		{"tmux without TERM_PROGRAM", 32,
+			map[string]string{"TERM": "tmux-256color"}, "tmux-256color"},
+		{"screen in alacritty", 14,
+			map[string]string{"TERM": "screen"}, "Alacritty"},
+		{"ssh stops at sshd", 43,
+			map[string]string{"TERM": "xterm-256color"}, "xterm-256color"},
+		{"sudo in konsole", 53, nil, "Konsole"},
+		{"truncated comm", 62, nil, "GNOME Terminal"},
+		{"comm with parentheses", 71,
+			map[string]string{"TERM_PROGRAM": "iTerm.app", "TERM": "xterm"}, "iTerm2"},
+		{"unknown TERM_PROGRAM", 999,
+			map[string]string{"TERM_PROGRAM": "Hyper", "TERM": "xterm"}, "Hyper"},
+		{"nothing known", 999, nil, ""},
+	}
+
+	for _, tt := range tests {
+		getenv := func(k string) string { return tt.env[k] }
+		if got := getTerminal(procFixture, tt.pid, getenv); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProcAncestors(t *testing.T) {
+	var comms []string
+	for _, p := range procAncestors(procFixture, 71) {
+		comms = append(comms, p.comm)
+	}
+	if len(comms) != 2 || comms[0] != "weird) (name" || comms[1] != "systemd" {
+		t.Errorf("got ancestors %q, want %q", comms, []string{"weird) (name", "systemd"})
+	}
+
+	if p := procAncestors(procFixture, 0); len(p) != 0 {
+		t.Errorf("got ancestors %v for pid 0, want none", p)
+	}
+}
@@ -0,0 +1 @@
+[ { meta = { }; name = "hello-2.12"; out = { outPath = "/nix/store/c-hello"; }; outputs = [ "out" ]; system = "x86_64-linux"; type = "derivation"; } ]
@@ -0,0 +1,273 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// Theme is a named set of colors. Colors left empty
+// keep the value they had before the theme is applied.
+type Theme struct {
+	// Name is used to select the theme with --theme
+	Name   string
+	Colors Colors
+}
+
+var ErrUnknownTheme = func(n string) error {
+	return fmt.Errorf("unknown theme '%s'", n)
+}
+
+var ErrDuplicateTheme = func(n string) error {
+	return fmt.Errorf("theme '%s' is already registered", n)
+}
+
+var ErrInvalidTheme = func(f, reason string) error {
+	return fmt.Errorf("invalid theme file '%s': %s", f, reason)
+}
+
+// extension of theme files
+const themeExt = ".toml"
+
+// ThemesDir returns the directory holding the theme files which can be
+// selected by name, or an empty string when HOME isn't set
+func ThemesDir() string {
+	h := os.Getenv("HOME")
+	if h == "" {
+		return ""
+	}
+	return filepath.Join(h, ".config/archey-go/themes")
+}
+
+var (
+	themesMu sync.RWMutex
+	themes   []Theme
+)
+
+func init() {
+	RegisterTheme(Theme{Name: "default", Colors: New().Colors})
+	RegisterTheme(Theme{Name: "arch-blue", Colors: Colors{
+		Name: "75", Text: "255", Sep: "31", Body: []string{"75", "31"},
+	}})
+	RegisterTheme(Theme{Name: "mono", Colors: Colors{
+		Name: "white+b", Text: "white", Sep: "white", Body: []string{"white+h", "white"},
+	}})
+	RegisterTheme(Theme{Name: "solarized", Colors: Colors{
+		Name: "37", Text: "245", Sep: "136", Body: []string{"33", "37"},
+	}})
+	RegisterTheme(Theme{Name: "gruvbox", Colors: Colors{
+		Name: "208", Text: "223", Sep: "243", Body: []string{"214", "208"},
+	}})
+}
+
+// RegisterTheme adds t to the list of built-in themes.
+// It panics if a theme with the same name is already registered.
+func RegisterTheme(t Theme) {
+	themesMu.Lock()
+	defer themesMu.Unlock()
+
+	for _, r := range themes {
+		if r.Name == t.Name {
+			panic(ErrDuplicateTheme(t.Name))
+		}
+	}
+
+	themes = append(themes, t)
+}
+
+// Themes returns all built-in themes in registration order
+func Themes() []Theme {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+
+	sl := make([]Theme, len(themes))
+	copy(sl, themes)
+	return sl
+}
+
+// LookupTheme returns the built-in theme registered under name
+func LookupTheme(name string) (Theme, bool) {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+
+	for _, t := range themes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+
+	return Theme{}, false
+}
+
+// FindTheme returns the theme called name. Theme files in ThemesDir take
+// precedence over the built-in themes, and a name with a path separator
+// or the theme file extension is loaded as a theme file.
+func FindTheme(name string) (Theme, error) {
+	if strings.ContainsRune(name, os.PathSeparator) || filepath.Ext(name) == themeExt {
+		return LoadTheme(name)
+	}
+
+	if dir := ThemesDir(); dir != "" {
+		f := filepath.Join(dir, name+themeExt)
+		if _, err := os.Stat(f); err == nil {
+			return LoadTheme(f)
+		}
+	}
+
+	if t, ok := LookupTheme(name); ok {
+		return t, nil
+	}
+	return Theme{}, ErrUnknownTheme(name)
+}
+
+// LoadThemes loads every theme file in dir, sorted by name.
+// A missing or empty dir holds no themes.
+func LoadThemes(dir string) ([]Theme, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sl []Theme
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != themeExt {
+			continue
+		}
+
+		t, err := LoadTheme(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sl = append(sl, t)
+	}
+
+	sort.Slice(sl, func(i, j int) bool { return sl[i].Name < sl[j].Name })
+	return sl, nil
+}
+
+// LoadTheme reads a theme from the TOML file f, which holds the keys of
+// the [colors] section of the config file, either at the top level or
+// under [colors]. The theme is named after the file without its extension.
+func LoadTheme(f string) (Theme, error) {
+	b, err := ioutil.ReadFile(f)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	var values map[string]interface{}
+	if err := toml.Unmarshal(b, &values); err != nil {
+		return Theme{}, ErrInvalidTheme(f, err.Error())
+	}
+	if colors, ok := values["colors"].(map[string]interface{}); ok && len(values) == 1 {
+		values = colors
+	}
+
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	t := Theme{Name: strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))}
+	for _, key := range keys {
+		colors, err := themeColors(values[key])
+		if err != nil {
+			return Theme{}, ErrInvalidTheme(f, key+": "+err.Error())
+		}
+
+		if key != "body_color" && len(colors) != 1 {
+			return Theme{}, ErrInvalidTheme(f, key+" takes a single color")
+		}
+
+		switch key {
+		case "name_color":
+			t.Colors.Name = colors[0]
+		case "text_color":
+			t.Colors.Text = colors[0]
+		case "sep_color":
+			t.Colors.Sep = colors[0]
+		case "body_color":
+			t.Colors.Body = colors
+		default:
+			return Theme{}, ErrInvalidTheme(f, "unknown key '"+key+"'")
+		}
+	}
+
+	return t, nil
+}
+
+// themeColors returns the colors of a decoded string or array of strings
+func themeColors(v interface{}) ([]string, error) {
+	var colors []string
+	switch v := v.(type) {
+	case string:
+		colors = []string{v}
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("expected a string")
+			}
+			colors = append(colors, s)
+		}
+	default:
+		return nil, errors.New("expected a string or an array of strings")
+	}
+
+	if len(colors) == 0 {
+		return nil, errors.New("missing color")
+	}
+	return colors, nil
+}
+
+// Apply sets the colors of c which the theme defines
+func (t Theme) Apply(c *Colors) {
+	if t.Colors.Name != "" {
+		c.Name = t.Colors.Name
+	}
+	if t.Colors.Text != "" {
+		c.Text = t.Colors.Text
+	}
+	if t.Colors.Sep != "" {
+		c.Sep = t.Colors.Sep
+	}
+	if len(t.Colors.Body) > 0 {
+		c.Body = t.Colors.Body
+	}
+}
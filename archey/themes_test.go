@@ -0,0 +1,103 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadThemes(t *testing.T) {
+	themes, err := LoadThemes("testdata/themes")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Theme{
+		{Name: "ocean", Colors: Colors{Name: "75", Text: "white+h", Body: []string{"75", "31"}}},
+		// the commas of a body color string are kept
+		{Name: "sunset", Colors: Colors{Sep: "#ff8800", Body: []string{"vertical(#ff5f00, #af005f)"}}},
+	}
+	if !reflect.DeepEqual(themes, want) {
+		t.Errorf("got %+v, want %+v", themes, want)
+	}
+}
+
+func TestLoadThemeInvalid(t *testing.T) {
+	files, err := filepath.Glob("testdata/themes/invalid/*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no invalid theme files")
+	}
+
+	for _, f := range files {
+		if theme, err := LoadTheme(f); err == nil {
+			t.Errorf("%s: got %+v, want an error", f, theme)
+		}
+	}
+}
+
+func TestFindThemeHome(t *testing.T) {
+	home, err := ioutil.TempDir("", "archey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	dir := filepath.Join(home, ".config/archey-go/themes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// a theme file takes precedence over the built-in theme
+	if err := ioutil.WriteFile(filepath.Join(dir, "mono.toml"), []byte("name_color = \"75\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	setenv(t, map[string]string{"HOME": home})
+	if got := ThemesDir(); got != dir {
+		t.Errorf("ThemesDir() = %q, want %q", got, dir)
+	}
+	theme, err := FindTheme("mono")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Colors.Name != "75" {
+		t.Errorf("got %+v, want the theme file", theme)
+	}
+
+	setenv(t, map[string]string{"HOME": ""})
+	if got := ThemesDir(); got != "" {
+		t.Errorf("ThemesDir() = %q, want none", got)
+	}
+	if theme, err = FindTheme("mono"); err != nil {
+		t.Fatal(err)
+	}
+	if builtin, _ := LookupTheme("mono"); !reflect.DeepEqual(theme, builtin) {
+		t.Errorf("got %+v, want the built-in theme", theme)
+	}
+}
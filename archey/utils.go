@@ -25,86 +25,248 @@ package archey
 import (
 	"bufio"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
-	utils "github.com/alexdreptu/utils-go"
 	"github.com/mgutz/ansi"
 )
 
 type GTK struct {
-	Theme  string
-	Icons  string
-	Font   string
-	Cursor string
+	Theme  string `json:"theme"`
+	Icons  string `json:"icons"`
+	Font   string `json:"font"`
+	Cursor string `json:"cursor"`
 }
 
 var ErrFileEmpty = func(f string) error {
 	return fmt.Errorf("file '%s' is empty", f)
 }
 
-// GetWM returns Window Manager name
-func GetWM() string {
-	wm := "None"
-	wmList := map[string]string{
-		"awesome":       "Awesome",
-		"blackbox":      "Blackbox",
-		"bspwm":         "bspwm",
-		"dwm":           "DWM",
-		"enlightenment": "Enlightenment",
-		"fluxbox":       "Fluxbox",
-		"fvwm":          "FVWM",
-		"herbstluftwm":  "herbstluftwm",
-		"i3":            "i3",
-		"icewm":         "IceWM",
-		"kwin":          "KWin",
-		"metacity":      "Metacity",
-		"musca":         "Musca",
-		"openbox":       "Openbox",
-		"pekwm":         "PekWM",
-		"ratpoison":     "ratpoison",
-		"scrotwm":       "ScrotWM",
-		"subtle":        "subtle",
-		"monsterwm":     "MonsterWM",
-		"wmaker":        "Window Maker",
-		"wmfs":          "Wmfs",
-		"wmii":          "wmii",
-		"xfwm4":         "Xfwm",
-		"mutter":        "Mutter",
-		"qtile":         "QTile",
-		"wingo":         "Wingo",
-	}
-
-	for k, v := range wmList {
-		if utils.IsExistProcName(k) {
-			wm = v
-			break
+var ErrInvalidStat = func(pid int) error {
+	return fmt.Errorf("invalid stat of process %d", pid)
+}
+
+var ErrInvalidUptime = func(f string) error {
+	return fmt.Errorf("invalid uptime in '%s'", f)
+}
+
+// system files read when a sysroot is set
+const (
+	procDir       = "/proc"
+	procUptime    = "/proc/uptime" // seconds since boot and seconds spent idle
+	procMeminfo   = "/proc/meminfo"
+	procCPUInfo   = "/proc/cpuinfo"
+	procOSRelease = "/proc/sys/kernel/osrelease"
+	procHostname  = "/proc/sys/kernel/hostname"
+	etcHostname   = "/etc/hostname"
+)
+
+// readUptime returns the time elapsed since boot as read from f
+func readUptime(f string) (time.Duration, error) {
+	b, err := ioutil.ReadFile(f)
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return 0, ErrInvalidUptime(f)
+	}
+
+	secs, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, ErrInvalidUptime(f)
+	}
+
+	return time.Duration(secs * float64(time.Second)), nil
+}
+
+// readFirstLine returns the first line of f
+func readFirstLine(f string) (string, error) {
+	file, err := os.Open(f)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", ErrFileEmpty(f)
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+// readMemInfo returns the memory and swap usage as read from /proc/meminfo
+func readMemInfo(f string) (mem, swap *Usage, err error) {
+	file, err := os.Open(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	// values are in kB
+	kb := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		n, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		kb[strings.TrimSuffix(fields[0], ":")] = n
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// MemAvailable was added in Linux 3.14
+	avail, ok := kb["MemAvailable"]
+	if !ok {
+		avail = kb["MemFree"] + kb["Buffers"] + kb["Cached"]
+	}
+	if avail > kb["MemTotal"] {
+		avail = kb["MemTotal"]
+	}
+	if kb["SwapFree"] > kb["SwapTotal"] {
+		kb["SwapFree"] = kb["SwapTotal"]
+	}
+
+	mem = &Usage{
+		Used:  (kb["MemTotal"] - avail) * 1024,
+		Total: kb["MemTotal"] * 1024,
+	}
+	swap = &Usage{
+		Used:  (kb["SwapTotal"] - kb["SwapFree"]) * 1024,
+		Total: kb["SwapTotal"] * 1024,
+	}
+	return mem, swap, nil
+}
+
+// readCPUName returns the model name of the first CPU in /proc/cpuinfo
+func readCPUName(f string) (string, error) {
+	file, err := os.Open(f)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 2)
+		if len(fields) != 2 {
+			continue
 		}
+
+		switch strings.TrimSpace(fields[0]) {
+		// x86, arm64 and powerpc
+		case "model name", "Model", "cpu":
+			return strings.TrimSpace(fields[1]), nil
+		}
+	}
+
+	return "", scanner.Err()
+}
+
+// maximum number of parents walked up from a process
+const maxProcDepth = 64
+
+// process is an entry of the proc file system
+type process struct {
+	pid  int
+	ppid int
+	comm string
+}
+
+// readProcStat reads the name and parent of the process pid
+// from the proc file system mounted at dir
+func readProcStat(dir string, pid int) (process, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return process{}, err
+	}
+
+	// pid (comm) state ppid ..., comm can contain spaces and parentheses
+	stat := string(b)
+	open := strings.IndexByte(stat, '(')
+	end := strings.LastIndexByte(stat, ')')
+	if open < 0 || end < open {
+		return process{}, ErrInvalidStat(pid)
 	}
 
-	return wm
+	fields := strings.Fields(stat[end+1:])
+	if len(fields) < 2 {
+		return process{}, ErrInvalidStat(pid)
+	}
+
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return process{}, ErrInvalidStat(pid)
+	}
+
+	return process{pid: pid, ppid: ppid, comm: stat[open+1 : end]}, nil
 }
 
-// GetDE returns the Desktop Environment name
-func GetDE() string {
-	de := "None"
-	deList := map[string]string{
-		"cinnamon":      "Cinnamon",
-		"gnome-session": "GNOME",
-		"ksmserver":     "KDE",
-		"mate-session":  "MATE",
-		"xfce4-session": "Xfce",
-		"lxsession":     "LXDE",
-	}
-
-	for k, v := range deList {
-		if utils.IsExistProcName(k) {
-			de = v
+// procAncestors returns the parents of the process pid starting with
+// the closest one, as read from the proc file system mounted at dir.
+// A pid of 0 has no parents.
+func procAncestors(dir string, pid int) []process {
+	var ancestors []process
+	if pid <= 0 {
+		return ancestors
+	}
+
+	for i := 0; i < maxProcDepth; i++ {
+		p, err := readProcStat(dir, pid)
+		if err != nil || p.ppid <= 0 {
+			break
+		}
+
+		parent, err := readProcStat(dir, p.ppid)
+		if err != nil {
 			break
 		}
+		ancestors = append(ancestors, parent)
+		pid = parent.pid
+	}
+
+	return ancestors
+}
+
+// procNames returns the names of the processes running
+// on the system whose proc file system is mounted at dir
+func procNames(dir string) map[string]bool {
+	names := make(map[string]bool)
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return names
+	}
+
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err != nil {
+			continue
+		}
+
+		comm, err := ioutil.ReadFile(filepath.Join(dir, e.Name(), "comm"))
+		if err != nil {
+			continue
+		}
+		names[strings.TrimSpace(string(comm))] = true
 	}
 
-	return de
+	return names
 }
 
 // GetGTKInfo reads gtkrc and returns a GTK type
@@ -165,5 +327,6 @@ func ListColors() {
 }
 
 func NoColor() {
+	plain = true
 	ansi.DisableColors(true)
 }
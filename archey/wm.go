@@ -0,0 +1,166 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"os"
+	"strings"
+)
+
+// display protocols
+const (
+	protoX11     = "X11"
+	protoWayland = "Wayland"
+)
+
+// windowManager is a window manager or compositor
+// identified by the name of its process
+type windowManager struct {
+	proc string
+	name string
+	// proto is the display protocol, empty for the ones which
+	// run on both and get it from the session type
+	proto string
+}
+
+// wayland compositors, checked before the X11 window managers
+// since some of them keep X11 window managers around for XWayland
+var waylandCompositors = []windowManager{
+	{"sway", "Sway", protoWayland},
+	{"Hyprland", "Hyprland", protoWayland},
+	{"river", "river", protoWayland},
+	{"wayfire", "Wayfire", protoWayland},
+	{"labwc", "labwc", protoWayland},
+	{"niri", "niri", protoWayland},
+	{"kwin_wayland", "KWin", protoWayland},
+	{"cosmic-comp", "cosmic-comp", protoWayland},
+	{"dwl", "dwl", protoWayland},
+	{"hikari", "hikari", protoWayland},
+	{"cage", "Cage", protoWayland},
+	{"weston", "Weston", protoWayland},
+	{"gnome-shell", "Mutter", ""},
+}
+
+var x11WindowManagers = []windowManager{
+	{"awesome", "Awesome", protoX11},
+	{"blackbox", "Blackbox", protoX11},
+	{"bspwm", "bspwm", protoX11},
+	{"dwm", "DWM", protoX11},
+	{"enlightenment", "Enlightenment", ""},
+	{"fluxbox", "Fluxbox", protoX11},
+	{"fvwm", "FVWM", protoX11},
+	{"herbstluftwm", "herbstluftwm", protoX11},
+	{"i3", "i3", protoX11},
+	{"icewm", "IceWM", protoX11},
+	{"kwin_x11", "KWin", protoX11},
+	{"kwin", "KWin", ""},
+	{"metacity", "Metacity", protoX11},
+	{"musca", "Musca", protoX11},
+	{"openbox", "Openbox", protoX11},
+	{"pekwm", "PekWM", protoX11},
+	{"ratpoison", "ratpoison", protoX11},
+	{"scrotwm", "ScrotWM", protoX11},
+	{"subtle", "subtle", protoX11},
+	{"monsterwm", "MonsterWM", protoX11},
+	{"wmaker", "Window Maker", protoX11},
+	{"wmfs", "Wmfs", protoX11},
+	{"wmii", "wmii", protoX11},
+	{"xfwm4", "Xfwm", protoX11},
+	{"mutter", "Mutter", ""},
+	{"qtile", "QTile", ""},
+	{"wingo", "Wingo", protoX11},
+}
+
+// environment variables set by compositors for their clients
+var compositorEnv = []struct {
+	env  string
+	name string
+}{
+	{"SWAYSOCK", "Sway"},
+	{"HYPRLAND_INSTANCE_SIGNATURE", "Hyprland"},
+	{"NIRI_SOCKET", "niri"},
+	{"WAYFIRE_SOCKET", "Wayfire"},
+	{"LABWC_PID", "labwc"},
+}
+
+// GetWM returns the Window Manager name along with
+// the display protocol it runs on, e.g. Sway (Wayland)
+func GetWM() string {
+	return getWM(procDir, os.Getenv)
+}
+
+// getWM returns the Window Manager name found from the environment
+// and the proc file system mounted at dir
+func getWM(dir string, getenv func(string) string) string {
+	session := sessionType(getenv)
+
+	for _, c := range compositorEnv {
+		if getenv(c.env) != "" {
+			return c.name + " (" + protoWayland + ")"
+		}
+	}
+
+	lists := [][]windowManager{waylandCompositors, x11WindowManagers}
+	if session == protoX11 {
+		lists[0], lists[1] = lists[1], lists[0]
+	}
+
+	procs := procNames(dir)
+	for _, list := range lists {
+		for _, wm := range list {
+			if !procs[wm.proc] {
+				continue
+			}
+
+			proto := wm.proto
+			if proto == "" {
+				proto = session
+			}
+			if proto == "" {
+				return wm.name
+			}
+			return wm.name + " (" + proto + ")"
+		}
+	}
+
+	return "None"
+}
+
+// sessionType returns the display protocol of the graphical session
+// or an empty string if it can't be determined
+func sessionType(getenv func(string) string) string {
+	switch strings.ToLower(getenv("XDG_SESSION_TYPE")) {
+	case "wayland":
+		return protoWayland
+	case "x11":
+		return protoX11
+	}
+
+	if getenv("WAYLAND_DISPLAY") != "" {
+		return protoWayland
+	}
+	if getenv("DISPLAY") != "" {
+		return protoX11
+	}
+	return ""
+}
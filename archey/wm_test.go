@@ -0,0 +1,66 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package archey
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// desktopFixture holds the root file systems of the desktop tests
+const desktopFixture = "testdata/desktop"
+
+func TestGetWM(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+		env  map[string]string
+		want string
+	}{
+		{"compositor socket", "gnome",
+			map[string]string{"SWAYSOCK": "/run/user/1000/sway-ipc.sock"}, "Sway (Wayland)"},
+		{"protocol from the session type", "gnome",
+			map[string]string{"XDG_SESSION_TYPE": "wayland"}, "Mutter (Wayland)"},
+		{"protocol from the display", "gnome",
+			map[string]string{"DISPLAY": ":0"}, "Mutter (X11)"},
+		{"unknown protocol", "gnome", nil, "Mutter"},
+		{"kwin", "plasma",
+			map[string]string{"XDG_SESSION_TYPE": "x11"}, "KWin (Wayland)"},
+		{"compositors come first", "mixed",
+			map[string]string{"WAYLAND_DISPLAY": "wayland-1"}, "Sway (Wayland)"},
+		{"x11 session", "mixed",
+			map[string]string{"XDG_SESSION_TYPE": "x11"}, "i3 (X11)"},
+		{"budgie runs mutter", "budgie",
+			map[string]string{"XDG_SESSION_TYPE": "x11"}, "Mutter (X11)"},
+		{"only pid directories", "self", nil, "None"},
+		{"no proc", "missing", nil, "None"},
+	}
+
+	for _, tt := range tests {
+		getenv := func(k string) string { return tt.env[k] }
+		dir := filepath.Join(desktopFixture, tt.root, procDir)
+		if got := getWM(dir, getenv); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	archey "github.com/alexdreptu/archey-go/archey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var logosCmd = &cobra.Command{
+	Use:   "logos",
+	Short: "List the available logos",
+	Long: `List the available logos and the os-release IDs they are used for.
+The logo of the running distribution, or of the one at --sysroot,
+is marked with *.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		detected := archey.DetectLogo(viper.GetString("options.sysroot"))
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "  NAME\tDISTRO\tOS-RELEASE IDS")
+		for _, l := range archey.Logos() {
+			mark := " "
+			if l.Name == detected.Name {
+				mark = "*"
+			}
+			fmt.Fprintf(w, "%s %s\t%s\t%s\n",
+				mark, l.Name, l.Distro, strings.Join(l.IDs, ", "))
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(logosCmd)
+}
@@ -0,0 +1,47 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogosSysroot(t *testing.T) {
+	var out bytes.Buffer
+	if err := execute(t, &out, "logos", "--sysroot", "../archey/testdata/sysroot"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the fixture's os-release describes Arch Linux, whatever the host runs
+	var marked []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		if strings.HasPrefix(line, "*") {
+			marked = append(marked, strings.Fields(line)[1])
+		}
+	}
+	if len(marked) != 1 || marked[0] != "arch" {
+		t.Errorf("got %v marked, want [arch]:\n%s", marked, out.String())
+	}
+}
@@ -23,8 +23,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	archey "github.com/alexdreptu/archey-go/archey"
 	"github.com/spf13/cobra"
@@ -45,13 +48,19 @@ Author: {{author}}
 URL: {{url}}
 
 Usage:
-      {{.Name}} [flags]
-
+      {{.CommandPath}} [flags]{{if .HasAvailableSubCommands}}
+      {{.CommandPath}} [command]{{end}}
+{{if .HasExample}}
 Example:
       {{.Name}} {{.Example}}
-
+{{end}}{{if .HasAvailableSubCommands}}
+Commands:{{range .Commands}}{{if .IsAvailableCommand}}
+      {{rpad .Name .NamePadding}} {{.Short}}{{end}}{{end}}
+{{end}}
 Flags:
-{{.Flags.FlagUsages}}
+{{.LocalFlags.FlagUsages}}{{if .HasAvailableInheritedFlags}}
+Global Flags:
+{{.InheritedFlags.FlagUsages}}{{end}}
 Report bugs to {{bugsUrl}}
 `
 
@@ -64,97 +73,252 @@ var RootCmd = &cobra.Command{
 			os.Exit(0)
 		}
 
-		opt := archey.New()
-
-		opt.Show.OS = viper.GetBool("show.no_os")
-		opt.Show.Arch = viper.GetBool("show.no_arch")
-		opt.Show.Kernel = viper.GetBool("show.no_kernel")
-		opt.Show.User = viper.GetBool("show.no_user")
-		opt.Show.Hostname = viper.GetBool("show.no_hostname")
-		opt.Show.Uptime = viper.GetBool("show.no_uptime")
-		opt.Show.UpSince = viper.GetBool("show.no_up_since")
-		opt.Show.WM = viper.GetBool("show.no_wm")
-		opt.Show.DE = viper.GetBool("show.no_de")
-		opt.Show.GTK2Theme = viper.GetBool("show.no_gtk2_theme")
-		opt.Show.GTK2IconTheme = viper.GetBool("show.no_gtk2_icon_theme")
-		opt.Show.GTK2Font = viper.GetBool("show.no_gtk2_font")
-		opt.Show.GTK2CursorTheme = viper.GetBool("show.no_gtk2_cursor_theme")
-		opt.Show.GTK3Theme = viper.GetBool("show.no_gtk3_theme")
-		opt.Show.GTK3IconTheme = viper.GetBool("show.no_gtk3_icon_theme")
-		opt.Show.GTK3Font = viper.GetBool("show.no_gtk3_font")
-		opt.Show.GTK3CursorTheme = viper.GetBool("show.no_gtk3_cursor_theme")
-		opt.Show.Terminal = viper.GetBool("show.no_terminal")
-		opt.Show.Shell = viper.GetBool("show.no_shell")
-		opt.Show.Editor = viper.GetBool("show.no_editor")
-		opt.Show.Packages = viper.GetBool("show.no_packages")
-		opt.Show.Memory = viper.GetBool("show.no_memory")
-		opt.Show.Swap = viper.GetBool("show.no_swap")
-		opt.Show.CPU = viper.GetBool("show.no_cpu")
-		opt.Show.Root = viper.GetBool("show.no_root")
-		opt.Show.Home = viper.GetBool("show.no_home")
-
-		if viper.GetString("options.sep") != "" {
-			opt.Sep = viper.GetString("options.sep")
+		opt, err := newOptions()
+		if err != nil {
+			return err
+		}
+
+		if viper.GetBool("options.no_color") {
+			archey.NoColor()
 		}
 
-		if viper.GetString("options.memory_unit") != "" {
-			opt.MemoryUnit = viper.GetString("options.memory_unit")
+		if cmd.Flag("list-colors").Changed {
+			archey.ListColors()
+			os.Exit(1)
 		}
 
-		if viper.GetString("options.swap_unit") != "" {
-			opt.SwapUnit = viper.GetString("options.swap_unit")
+		if cmd.Flag("version").Changed {
+			fmt.Println("Archey-go v" + version)
+			os.Exit(0)
 		}
 
-		if viper.GetString("options.disk_unit") != "" {
-			opt.DiskUnit = viper.GetString("options.disk_unit")
+		output := viper.GetString("options.output")
+		if interval := viper.GetDuration("options.watch"); interval > 0 {
+			if output != "" && strings.ToLower(output) != "logo" {
+				return ErrWatchOutput(output)
+			}
+			return watch(opt, interval)
 		}
 
-		opt.Paths = viper.GetStringSlice("options.paths")
-		opt.PathFull = viper.GetBool("options.path_full")
-		opt.ShellFull = viper.GetBool("options.shell_full")
+		return printOutput(opt, output)
+	},
+}
 
-		if viper.GetString("options.up_since_format") != "" {
-			opt.UpSinceFormat = viper.GetString("options.up_since_format")
-		}
+var ErrInvalidOutput = func(o string) error {
+	return fmt.Errorf("invalid output format '%s'", o)
+}
+
+var ErrInvalidTimeout = func(name, t string) error {
+	return fmt.Errorf("invalid timeout '%s' for '%s'", t, name)
+}
+
+// newOptions returns the options set by the flags and the config file
+func newOptions() (*archey.Options, error) {
+	opt := archey.New()
+
+	for _, p := range archey.Providers() {
+		opt.Hide[p.Name()] = viper.GetBool("show.no_" + p.Name())
+	}
+	opt.NoArch = viper.GetBool("show.no_arch")
+
+	if viper.GetString("options.sep") != "" {
+		opt.Sep = viper.GetString("options.sep")
+	}
+
+	if viper.GetString("options.memory_unit") != "" {
+		opt.MemoryUnit = viper.GetString("options.memory_unit")
+	}
+
+	if viper.GetString("options.swap_unit") != "" {
+		opt.SwapUnit = viper.GetString("options.swap_unit")
+	}
+
+	if viper.GetString("options.disk_unit") != "" {
+		opt.DiskUnit = viper.GetString("options.disk_unit")
+	}
+
+	opt.Paths = viper.GetStringSlice("options.paths")
+	opt.NoPackageManagers = viper.GetStringSlice("options.no_package_managers")
+	opt.PathFull = viper.GetBool("options.path_full")
+	opt.ShellFull = viper.GetBool("options.shell_full")
+	opt.Modules = viper.GetStringSlice("options.modules")
+	opt.Interfaces = viper.GetStringSlice("options.interfaces")
+	opt.MaskAddresses = viper.GetBool("options.mask_addresses")
+
+	if viper.IsSet("options.timeout") {
+		opt.Timeout = viper.GetDuration("options.timeout")
+	}
 
-		if viper.GetString("colors.name_color") != "" {
-			opt.Colors.Name = viper.GetString("colors.name_color")
+	for name, timeout := range viper.GetStringMapString("timeouts") {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, ErrInvalidTimeout(name, timeout)
 		}
+		opt.Timeouts[name] = d
+	}
 
-		if viper.GetString("colors.text_color") != "" {
-			opt.Colors.Text = viper.GetString("colors.text_color")
+	custom, err := customLines()
+	if err != nil {
+		return nil, err
+	}
+	opt.Custom = custom
+
+	opt.Sysroot = viper.GetString("options.sysroot")
+	opt.Logo = viper.GetString("options.logo")
+	opt.LogoFile = viper.GetString("options.logo_file")
+	opt.Image = viper.GetString("options.image")
+	opt.ImageProtocol = viper.GetString("options.image_protocol")
+	opt.ImageWidth = viper.GetInt("options.image_width")
+
+	if viper.GetString("options.up_since_format") != "" {
+		opt.UpSinceFormat = viper.GetString("options.up_since_format")
+	}
+
+	if theme := viper.GetString("options.theme"); theme != "" {
+		t, err := archey.FindTheme(theme)
+		if err != nil {
+			return nil, err
 		}
+		t.Apply(&opt.Colors)
+	}
+
+	// the individual colors override the theme
+	if viper.GetString("colors.name_color") != "" {
+		opt.Colors.Name = viper.GetString("colors.name_color")
+	}
 
-		if viper.GetString("colors.sep_color") != "" {
-			opt.Colors.Sep = viper.GetString("colors.sep_color")
+	if viper.GetString("colors.text_color") != "" {
+		opt.Colors.Text = viper.GetString("colors.text_color")
+	}
+
+	if viper.GetString("colors.sep_color") != "" {
+		opt.Colors.Sep = viper.GetString("colors.sep_color")
+	}
+
+	if body := bodyColor(); len(body) != 0 {
+		opt.Colors.Body = body
+	}
+
+	return opt, nil
+}
+
+// bodyColor returns the body color of the flag or the config. A string of
+// the config is kept whole, as GetStringSlice would split it on its spaces,
+// e.g. "vertical(#1793d1, #ffffff)". An array and the flag, which is split on
+// its commas, are joined back with commas by Options.RenderInfo.
+func bodyColor() []string {
+	if s, ok := viper.Get("colors.body_color").(string); ok {
+		if strings.TrimSpace(s) == "" {
+			return nil
 		}
+		return []string{s}
+	}
+	return viper.GetStringSlice("colors.body_color")
+}
+
+// customLines returns the [[custom]] lines of the config
+func customLines() ([]archey.Custom, error) {
+	var lines []struct {
+		Label   string
+		Text    string
+		File    string
+		Command string
+		Timeout string
+	}
+	if err := viper.UnmarshalKey("custom", &lines); err != nil {
+		return nil, err
+	}
 
-		if len(viper.GetStringSlice("colors.body_color")) != 0 {
-			opt.Colors.Body = viper.GetStringSlice("colors.body_color")
+	var custom []archey.Custom
+	for _, l := range lines {
+		c := archey.Custom{Label: l.Label, Text: l.Text, File: l.File, Command: l.Command}
+		if l.Timeout != "" {
+			d, err := time.ParseDuration(l.Timeout)
+			if err != nil {
+				return nil, ErrInvalidTimeout(l.Label, l.Timeout)
+			}
+			c.Timeout = d
 		}
+		custom = append(custom, c)
+	}
 
-		if viper.GetBool("options.no_color") {
-			archey.NoColor()
+	return custom, nil
+}
+
+// printOutput prints the info in the requested output format
+func printOutput(opt *archey.Options, output string) error {
+	switch strings.ToLower(output) {
+	case "", "logo":
+		info, err := opt.Render()
+		if err != nil {
+			return err
 		}
 
-		if cmd.Flag("list-colors").Changed {
-			archey.ListColors()
-			os.Exit(1)
+		fmt.Println(info)
+	case "json":
+		si, err := archey.Collect(opt)
+		if err != nil {
+			return err
 		}
 
-		if cmd.Flag("version").Changed {
-			fmt.Println("Archey-go v" + version)
-			os.Exit(0)
+		b, err := json.MarshalIndent(si, "", "  ")
+		if err != nil {
+			return err
 		}
 
-		info, err := opt.Render()
+		fmt.Println(string(b))
+	case "prometheus":
+		si, err := archey.Collect(opt)
 		if err != nil {
 			return err
 		}
 
-		fmt.Println(info)
-		return nil
-	},
+		fmt.Print(si.Prometheus())
+	default:
+		return ErrInvalidOutput(output)
+	}
+
+	return nil
+}
+
+// Execute adds a --no-<name> flag for every registered provider
+// and runs the root command
+func Execute() error {
+	addProviderFlags()
+	RootCmd.SetArgs(watchArgs(os.Args[1:]))
+	return RootCmd.Execute()
+}
+
+// watchArgs joins --watch and the interval following it, as a flag
+// with an optional value only takes it in the --watch=5s form
+func watchArgs(args []string) []string {
+	var sl []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--watch" && i+1 < len(args) {
+			if _, err := time.ParseDuration(args[i+1]); err == nil {
+				sl = append(sl, "--watch="+args[i+1])
+				i++
+				continue
+			}
+		}
+		sl = append(sl, args[i])
+	}
+	return sl
+}
+
+// addProviderFlags generates the persistent flags and config keys of the info fields
+// from the provider registry, so fields registered outside of archey get
+// them too. It must run after all the providers have been registered.
+func addProviderFlags() {
+	for _, p := range archey.Providers() {
+		flag := "no-" + strings.Replace(p.Name(), "_", "-", -1)
+		if RootCmd.PersistentFlags().Lookup(flag) != nil {
+			continue
+		}
+
+		RootCmd.PersistentFlags().Bool(flag, false, "don't print "+p.Label())
+		viper.BindPFlag("show.no_"+p.Name(), RootCmd.PersistentFlags().Lookup(flag))
+	}
 }
 
 func init() {
@@ -166,90 +330,79 @@ func init() {
 	RootCmd.Example = `--body-color 111 --name-color 150 --sep ' ->' --sep-color 191 \
 	--shell-full --memory-unit mb --no-swap --paths /tmp,/usr --path-full`
 	RootCmd.SetUsageTemplate(usageTemplate)
-	RootCmd.Flags().Bool("no-os", false, "don't print os name")
-	RootCmd.Flags().Bool("no-arch", false, "don't print architecture")
-	RootCmd.Flags().Bool("no-kernel", false, "don't print kernel version")
-	RootCmd.Flags().Bool("no-user", false, "don't print user")
-	RootCmd.Flags().Bool("no-hostname", false, "don't print hostname")
-	RootCmd.Flags().Bool("no-uptime", false, "don't print uptime")
-	RootCmd.Flags().Bool("no-up-since", false, "don't print up since")
-	RootCmd.Flags().Bool("no-wm", false, "don't print Window Manager name")
-	RootCmd.Flags().Bool("no-de", false, "don't print Desktop Environment name")
-	RootCmd.Flags().Bool("no-gtk2-theme", false, "don't print GTK2 theme name")
-	RootCmd.Flags().Bool("no-gtk2-icon-theme", false, "don't print GTK2 icon theme name")
-	RootCmd.Flags().Bool("no-gtk2-font", false, "don't print GTK2 font name")
-	RootCmd.Flags().Bool("no-gtk2-cursor-theme", false, "don't print GTK2 cursor theme name")
-	RootCmd.Flags().Bool("no-gtk3-theme", false, "don't print GTK3 theme name")
-	RootCmd.Flags().Bool("no-gtk3-icon-theme", false, "don't print GTK3 icon theme name")
-	RootCmd.Flags().Bool("no-gtk3-font", false, "don't print GTK3 font name")
-	RootCmd.Flags().Bool("no-gtk3-cursor-theme", false, "don't print GTK3 cursor theme name")
-	RootCmd.Flags().Bool("no-terminal", false, "don't print terminal name")
-	RootCmd.Flags().Bool("no-shell", false, "don't print shell name")
-	RootCmd.Flags().Bool("no-editor", false, "don't print editor name")
-	RootCmd.Flags().Bool("no-packages", false, "don't print packages count")
-	RootCmd.Flags().Bool("no-memory", false, "don't print memory usage")
-	RootCmd.Flags().Bool("no-swap", false, "don't print swap usage")
-	RootCmd.Flags().Bool("no-cpu", false, "don't print CPU model")
-	RootCmd.Flags().Bool("no-root", false, "don't print root disk usage")
-	RootCmd.Flags().Bool("no-home", false, "don't print home disk usage")
-	RootCmd.Flags().String("sep", "", "separator string")
-	RootCmd.Flags().String("memory-unit", "", "unit to use for memory usage")
-	RootCmd.Flags().String("swap-unit", "", "unit to use for swap usage")
-	RootCmd.Flags().String("disk-unit", "", "unit to use for disk usage")
-	RootCmd.Flags().StringSlice("paths", nil, "additional paths to add to disk usage info")
-	RootCmd.Flags().Bool("path-full", false, "show full paths")
-	RootCmd.Flags().Bool("shell-full", false, "print shell's full path instead of its name")
-	RootCmd.Flags().String("up-since-format", "", "strftime format for up since")
-	RootCmd.Flags().String("name-color", "", "color of the variable name")
-	RootCmd.Flags().String("text-color", "", "color of the text")
-	RootCmd.Flags().String("sep-color", "", "color of the separator")
-	RootCmd.Flags().StringSlice("body-color", nil, "color of the logo body")
-	RootCmd.Flags().BoolP("no-color", "n", false, "don't use any colors")
+
+	// the options are persistent, so that the subcommands
+	// collect and render the info with them too
+	RootCmd.PersistentFlags().Bool("no-arch", false, "don't print architecture")
+	RootCmd.PersistentFlags().String("sep", "", "separator string")
+	RootCmd.PersistentFlags().String("memory-unit", "", "unit to use for memory usage")
+	RootCmd.PersistentFlags().String("swap-unit", "", "unit to use for swap usage")
+	RootCmd.PersistentFlags().String("disk-unit", "", "unit to use for disk usage")
+	RootCmd.PersistentFlags().StringSlice("paths", nil, "additional paths to add to disk usage info")
+	RootCmd.PersistentFlags().StringSlice("no-package-managers", nil,
+		"package managers whose packages aren't counted: "+strings.Join(archey.PackageManagers(), ", "))
+	RootCmd.PersistentFlags().Bool("path-full", false, "show full paths")
+	RootCmd.PersistentFlags().Bool("shell-full", false, "print shell's full path instead of its name")
+	RootCmd.PersistentFlags().StringSlice("modules", nil,
+		"fields to show in order, along with separator and blank lines, e.g. os,kernel,separator,cpu")
+	RootCmd.PersistentFlags().StringSlice("interfaces", nil,
+		"network interfaces to show as glob patterns, ! excludes, e.g. 'en*,wl*,!docker*'")
+	RootCmd.PersistentFlags().Bool("mask-addresses", false, "hide the host part of network addresses")
+	RootCmd.PersistentFlags().String("up-since-format", "", "strftime format for up since")
+	RootCmd.PersistentFlags().String("theme", "", "color theme, either built-in or a file in ~/.config/archey-go/themes")
+	RootCmd.PersistentFlags().String("name-color", "", "color of the variable name")
+	RootCmd.PersistentFlags().String("text-color", "", "color of the text")
+	RootCmd.PersistentFlags().String("sep-color", "", "color of the separator")
+	RootCmd.PersistentFlags().StringSlice("body-color", nil, "color of the logo body")
+	RootCmd.PersistentFlags().Duration("timeout", 0, "how long each field may take to collect, e.g. 500ms (default 2s)")
+	RootCmd.PersistentFlags().StringToString("timeouts", nil, "timeouts of individual fields, e.g. paths=5s,wm=1s")
+	RootCmd.PersistentFlags().String("sysroot", "", "read system files relative to this directory")
+	RootCmd.PersistentFlags().String("logo", "", "logo to display instead of the distribution's one")
+	RootCmd.PersistentFlags().String("logo-file", "", "ASCII art file to use as logo")
+	RootCmd.PersistentFlags().String("image", "", "PNG, JPEG or GIF image to display instead of the logo")
+	RootCmd.PersistentFlags().String("image-protocol", "", "how to display the image: auto, kitty, sixel, iterm or blocks (default auto)")
+	RootCmd.PersistentFlags().Int("image-width", 0, "width of the image in columns (default 32)")
+	RootCmd.PersistentFlags().BoolP("no-color", "n", false, "don't use any colors")
+	RootCmd.PersistentFlags().StringVarP(&config, "config", "c", "", "config file")
+
+	// the flags of the root command alone
+	RootCmd.Flags().Duration("watch", 0, "redraw the info every interval until q is pressed, e.g. --watch 5s")
+	RootCmd.Flags().Lookup("watch").NoOptDefVal = defWatchInterval.String()
+	RootCmd.Flags().StringP("output", "o", "", "output format: logo, json or prometheus")
 	RootCmd.Flags().BoolP("list-colors", "l", false, "print all colors and styles")
 	RootCmd.Flags().BoolP("version", "v", false, "print version")
-	RootCmd.Flags().StringVarP(&config, "config", "c", "", "config file")
-
-	viper.BindPFlag("show.no_os", RootCmd.Flags().Lookup("no-os"))
-	viper.BindPFlag("show.no_arch", RootCmd.Flags().Lookup("no-arch"))
-	viper.BindPFlag("show.no_kernel", RootCmd.Flags().Lookup("no-kernel"))
-	viper.BindPFlag("show.no_user", RootCmd.Flags().Lookup("no-user"))
-	viper.BindPFlag("show.no_hostname", RootCmd.Flags().Lookup("no-hostname"))
-	viper.BindPFlag("show.no_uptime", RootCmd.Flags().Lookup("no-uptime"))
-	viper.BindPFlag("show.no_up_since", RootCmd.Flags().Lookup("no-up-since"))
-	viper.BindPFlag("show.no_wm", RootCmd.Flags().Lookup("no-wm"))
-	viper.BindPFlag("show.no_de", RootCmd.Flags().Lookup("no-de"))
-	viper.BindPFlag("show.no_gtk2_theme", RootCmd.Flags().Lookup("no-gtk2-theme"))
-	viper.BindPFlag("show.no_gtk2_icon_theme", RootCmd.Flags().Lookup("no-gtk2-icon-theme"))
-	viper.BindPFlag("show.no_gtk2_font", RootCmd.Flags().Lookup("no-gtk2-font"))
-	viper.BindPFlag("show.no_gtk2_cursor_theme", RootCmd.Flags().Lookup("no-gtk2-cursor-theme"))
-	viper.BindPFlag("show.no_gtk3_theme", RootCmd.Flags().Lookup("no-gtk3-theme"))
-	viper.BindPFlag("show.no_gtk3_icon_theme", RootCmd.Flags().Lookup("no-gtk3-icon-theme"))
-	viper.BindPFlag("show.no_gtk3_font", RootCmd.Flags().Lookup("no-gtk3-font"))
-	viper.BindPFlag("show.no_gtk3_cursor_theme", RootCmd.Flags().Lookup("no-gtk3-cursor-theme"))
-	viper.BindPFlag("show.no_terminal", RootCmd.Flags().Lookup("no-terminal"))
-	viper.BindPFlag("show.no_shell", RootCmd.Flags().Lookup("no-shell"))
-	viper.BindPFlag("show.no_editor", RootCmd.Flags().Lookup("no-editor"))
-	viper.BindPFlag("show.no_packages", RootCmd.Flags().Lookup("no-packages"))
-	viper.BindPFlag("show.no_memory", RootCmd.Flags().Lookup("no-memory"))
-	viper.BindPFlag("show.no_swap", RootCmd.Flags().Lookup("no-swap"))
-	viper.BindPFlag("show.no_cpu", RootCmd.Flags().Lookup("no-cpu"))
-	viper.BindPFlag("show.no_Root", RootCmd.Flags().Lookup("no-root"))
-	viper.BindPFlag("show.no_Home", RootCmd.Flags().Lookup("no-home"))
-
-	viper.BindPFlag("options.sep", RootCmd.Flags().Lookup("sep"))
-	viper.BindPFlag("options.memory_unit", RootCmd.Flags().Lookup("memory-unit"))
-	viper.BindPFlag("options.swap_unit", RootCmd.Flags().Lookup("swap-unit"))
-	viper.BindPFlag("options.disk_unit", RootCmd.Flags().Lookup("disk-unit"))
-	viper.BindPFlag("options.paths", RootCmd.Flags().Lookup("paths"))
-	viper.BindPFlag("options.path_full", RootCmd.Flags().Lookup("path-full"))
-	viper.BindPFlag("options.shell_full", RootCmd.Flags().Lookup("shell-full"))
-	viper.BindPFlag("options.up_since_format", RootCmd.Flags().Lookup("up-since-format"))
-	viper.BindPFlag("options.no_color", RootCmd.Flags().Lookup("no-color"))
-
-	viper.BindPFlag("colors.name_color", RootCmd.Flags().Lookup("name-color"))
-	viper.BindPFlag("colors.text_color", RootCmd.Flags().Lookup("text-color"))
-	viper.BindPFlag("colors.sep_color", RootCmd.Flags().Lookup("sep-color"))
-	viper.BindPFlag("colors.body_color", RootCmd.Flags().Lookup("body-color"))
+
+	viper.BindPFlag("show.no_arch", RootCmd.PersistentFlags().Lookup("no-arch"))
+
+	viper.BindPFlag("options.sep", RootCmd.PersistentFlags().Lookup("sep"))
+	viper.BindPFlag("options.memory_unit", RootCmd.PersistentFlags().Lookup("memory-unit"))
+	viper.BindPFlag("options.swap_unit", RootCmd.PersistentFlags().Lookup("swap-unit"))
+	viper.BindPFlag("options.disk_unit", RootCmd.PersistentFlags().Lookup("disk-unit"))
+	viper.BindPFlag("options.paths", RootCmd.PersistentFlags().Lookup("paths"))
+	viper.BindPFlag("options.no_package_managers", RootCmd.PersistentFlags().Lookup("no-package-managers"))
+	viper.BindPFlag("options.path_full", RootCmd.PersistentFlags().Lookup("path-full"))
+	viper.BindPFlag("options.shell_full", RootCmd.PersistentFlags().Lookup("shell-full"))
+	viper.BindPFlag("options.modules", RootCmd.PersistentFlags().Lookup("modules"))
+	viper.BindPFlag("options.interfaces", RootCmd.PersistentFlags().Lookup("interfaces"))
+	viper.BindPFlag("options.mask_addresses", RootCmd.PersistentFlags().Lookup("mask-addresses"))
+	viper.BindPFlag("options.up_since_format", RootCmd.PersistentFlags().Lookup("up-since-format"))
+	viper.BindPFlag("options.timeout", RootCmd.PersistentFlags().Lookup("timeout"))
+	viper.BindPFlag("timeouts", RootCmd.PersistentFlags().Lookup("timeouts"))
+	viper.BindPFlag("options.sysroot", RootCmd.PersistentFlags().Lookup("sysroot"))
+	viper.BindPFlag("options.logo", RootCmd.PersistentFlags().Lookup("logo"))
+	viper.BindPFlag("options.logo_file", RootCmd.PersistentFlags().Lookup("logo-file"))
+	viper.BindPFlag("options.image", RootCmd.PersistentFlags().Lookup("image"))
+	viper.BindPFlag("options.image_protocol", RootCmd.PersistentFlags().Lookup("image-protocol"))
+	viper.BindPFlag("options.image_width", RootCmd.PersistentFlags().Lookup("image-width"))
+	viper.BindPFlag("options.watch", RootCmd.Flags().Lookup("watch"))
+	viper.BindPFlag("options.output", RootCmd.Flags().Lookup("output"))
+	viper.BindPFlag("options.no_color", RootCmd.PersistentFlags().Lookup("no-color"))
+
+	viper.BindPFlag("options.theme", RootCmd.PersistentFlags().Lookup("theme"))
+	viper.BindPFlag("colors.name_color", RootCmd.PersistentFlags().Lookup("name-color"))
+	viper.BindPFlag("colors.text_color", RootCmd.PersistentFlags().Lookup("text-color"))
+	viper.BindPFlag("colors.sep_color", RootCmd.PersistentFlags().Lookup("sep-color"))
+	viper.BindPFlag("colors.body_color", RootCmd.PersistentFlags().Lookup("body-color"))
 }
 
 func initConfig() {
@@ -0,0 +1,126 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	archey "github.com/alexdreptu/archey-go/archey"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestNewOptionsBodyColor(t *testing.T) {
+	viper.SetConfigType("toml")
+	defer viper.ReadConfig(bytes.NewReader(nil))
+
+	tests := []struct {
+		config string
+		flag   string
+		want   []string
+	}{
+		{`body_color = "vertical(#1793d1, #ffffff)"`, "", []string{"vertical(#1793d1, #ffffff)"}},
+		{`body_color = "red+h, green"`, "", []string{"red+h, green"}},
+		{`body_color = ["red+h", "green"]`, "", []string{"red+h", "green"}},
+		{`body_color = "red+h, green"`, "vertical(#1793d1, #ffffff)",
+			[]string{"vertical(#1793d1", " #ffffff)"}},
+	}
+
+	// a set slice flag appends to its value, so every case parses the flag
+	// in a new flag set and swaps its value in
+	flag := RootCmd.PersistentFlags().Lookup("body-color")
+	defer func(v pflag.Value) {
+		flag.Value = v
+		flag.Changed = false
+	}(flag.Value)
+
+	for _, tt := range tests {
+		if err := viper.ReadConfig(bytes.NewBufferString("[colors]\n" + tt.config)); err != nil {
+			t.Fatal(err)
+		}
+		if tt.flag != "" {
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.StringSlice("body-color", nil, "")
+			if err := fs.Set("body-color", tt.flag); err != nil {
+				t.Fatal(err)
+			}
+			flag.Value = fs.Lookup("body-color").Value
+			flag.Changed = true
+		}
+
+		opt, err := newOptions()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.config, err)
+		}
+		if !reflect.DeepEqual(opt.Colors.Body, tt.want) {
+			t.Errorf("%s, flag %q: got %q, want %q", tt.config, tt.flag, opt.Colors.Body, tt.want)
+		}
+		if _, err := opt.RenderInfo(archey.SystemInfo{}); err != nil {
+			t.Errorf("%s, flag %q: %v", tt.config, tt.flag, err)
+		}
+	}
+}
+
+// execute runs the root command with args like Execute and resets
+// the flags and the config it read once the test ends
+func execute(t *testing.T, out io.Writer, args ...string) error {
+	t.Cleanup(func() {
+		reset := func(f *pflag.Flag) {
+			if !f.Changed {
+				return
+			}
+			// a set slice flag appends to its value
+			if v, ok := f.Value.(pflag.SliceValue); ok {
+				v.Replace(nil)
+			} else {
+				f.Value.Set(f.DefValue)
+			}
+			f.Changed = false
+		}
+		RootCmd.PersistentFlags().VisitAll(reset)
+		for _, c := range RootCmd.Commands() {
+			c.Flags().VisitAll(reset)
+		}
+		RootCmd.SetOut(nil)
+		viper.ReadConfig(bytes.NewReader(nil))
+	})
+
+	addProviderFlags()
+	RootCmd.SetOut(out)
+	RootCmd.SetArgs(args)
+	return RootCmd.Execute()
+}
+
+// writeConfig writes a config file to dir and returns its path
+func writeConfig(t *testing.T, dir, config string) string {
+	path := filepath.Join(dir, "config.toml")
+	if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,310 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	archey "github.com/alexdreptu/archey-go/archey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// default port of the serve subcommand and how long
+// the collected info answers the requests
+const (
+	defServePort  = 8787
+	defServeCache = time.Second
+)
+
+// formats of the /render endpoint
+const (
+	renderANSI  = "ansi"
+	renderPlain = "plain"
+)
+
+var ErrSocketInUse = func(path string) error {
+	return fmt.Errorf("'%s' exists and isn't a socket", path)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the info over HTTP",
+	Long: `Serve the info over HTTP on a port of localhost or on a unix socket.
+The info is collected at most once per --cache interval, and the requests
+arriving while it's collected share the result.
+
+  GET /info           all the fields as JSON, like --output json
+  GET /info/<field>   a single field of /info as JSON, e.g. /info/memory
+  GET /render         the logo and the info, ?format=plain without colors
+  GET /metrics        the numeric values in the Prometheus text format`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opt, err := newOptions()
+		if err != nil {
+			return err
+		}
+
+		if viper.GetBool("options.no_color") {
+			archey.NoColor()
+		}
+
+		// validate the options once instead of on every request
+		c := &collector{opt: opt, ttl: viper.GetDuration("serve.cache")}
+		if _, err := c.collect(); err != nil {
+			return err
+		}
+
+		ln, err := serveListener(viper.GetString("serve.socket"), viper.GetInt("serve.port"))
+		if err != nil {
+			return err
+		}
+
+		srv := &http.Server{Handler: newServeMux(c)}
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(signals)
+
+		go func() {
+			<-signals
+			srv.Shutdown(context.Background())
+		}()
+
+		fmt.Fprintf(os.Stderr, "listening on %s\n", ln.Addr())
+		if err := srv.Serve(ln); err != http.ErrServerClosed {
+			return err
+		}
+
+		return nil
+	},
+}
+
+// serveListener listens on the unix socket at path if it's set
+// and on port of localhost otherwise
+func serveListener(path string, port int) (net.Listener, error) {
+	if path == "" {
+		return net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(port)))
+	}
+
+	// a socket left behind by a server which didn't exit cleanly
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, ErrSocketInUse(path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	// the socket file is removed when the listener is closed
+	ln.(*net.UnixListener).SetUnlinkOnClose(true)
+
+	return ln, nil
+}
+
+// collector collects the info for the requests. The info is reused for
+// ttl and the requests arriving while it's collected wait for it, so that
+// polling doesn't run the shell, the custom commands and statfs of every
+// path for each request.
+type collector struct {
+	opt *archey.Options
+	ttl time.Duration
+
+	mu      sync.Mutex
+	si      archey.SystemInfo
+	at      time.Time
+	pending *collection
+}
+
+// collection is a collection in progress
+type collection struct {
+	done chan struct{}
+	si   archey.SystemInfo
+	err  error
+}
+
+func (c *collector) collect() (archey.SystemInfo, error) {
+	c.mu.Lock()
+	if !c.at.IsZero() && time.Since(c.at) < c.ttl {
+		si := c.si
+		c.mu.Unlock()
+		return si, nil
+	}
+	if call := c.pending; call != nil {
+		c.mu.Unlock()
+		<-call.done
+		return call.si, call.err
+	}
+	call := &collection{done: make(chan struct{})}
+	c.pending = call
+	c.mu.Unlock()
+
+	call.si, call.err = archey.Collect(c.opt)
+
+	c.mu.Lock()
+	if call.err == nil {
+		c.si, c.at = call.si, time.Now()
+	}
+	c.pending = nil
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.si, call.err
+}
+
+// newServeMux returns the handler of the serve subcommand endpoints
+func newServeMux(c *collector) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/info", getOnly(func(w http.ResponseWriter, r *http.Request) {
+		si, err := c.collect()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, si)
+	}))
+
+	mux.HandleFunc("/info/", getOnly(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/info/")
+
+		si, err := c.collect()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		v, ok := si.Field(key)
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown or hidden field '%s'", key), http.StatusNotFound)
+			return
+		}
+
+		writeJSON(w, v)
+	}))
+
+	mux.HandleFunc("/metrics", getOnly(func(w http.ResponseWriter, r *http.Request) {
+		si, err := c.collect()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprint(w, si.Prometheus())
+	}))
+
+	mux.HandleFunc("/render", getOnly(func(w http.ResponseWriter, r *http.Request) {
+		format := strings.ToLower(r.URL.Query().Get("format"))
+		if format == "" {
+			format = renderANSI
+		}
+
+		o := *c.opt
+		switch format {
+		case renderANSI:
+			// the other protocols depend on the terminal of the server
+			o.ImageProtocol = "blocks"
+		case renderPlain:
+			o.Image = ""
+		default:
+			http.Error(w, fmt.Sprintf("invalid format '%s'", format), http.StatusBadRequest)
+			return
+		}
+
+		si, err := c.collect()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		info, err := o.RenderInfo(si)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if format == renderPlain {
+			info = archey.StripColors(info)
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, info)
+	}))
+
+	return mux
+}
+
+// getOnly answers the requests other than GET and HEAD
+// with 405 Method Not Allowed
+func getOnly(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// writeJSON writes v indented like --output json
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(b, '\n'))
+}
+
+func init() {
+	serveCmd.Flags().IntP("port", "p", defServePort, "port of localhost to listen on")
+	serveCmd.Flags().StringP("socket", "s", "", "unix socket to listen on instead of a port")
+	serveCmd.Flags().Duration("cache", defServeCache, "how long the collected info answers the requests")
+
+	viper.BindPFlag("serve.port", serveCmd.Flags().Lookup("port"))
+	viper.BindPFlag("serve.socket", serveCmd.Flags().Lookup("socket"))
+	viper.BindPFlag("serve.cache", serveCmd.Flags().Lookup("cache"))
+
+	RootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,194 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	archey "github.com/alexdreptu/archey-go/archey"
+)
+
+func TestServeSharesCollections(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// every run of the command adds a line to runs
+	runs := filepath.Join(dir, "runs")
+	opt := archey.New()
+	opt.Modules = []string{"custom"}
+	opt.Custom = []archey.Custom{{
+		Label:   "Runs",
+		Command: "echo run >> " + runs + "; sleep 0.2; wc -l < " + runs,
+	}}
+
+	const ttl = 500 * time.Millisecond
+	srv := httptest.NewServer(newServeMux(&collector{opt: opt, ttl: ttl}))
+	defer srv.Close()
+
+	get := func(path string) string {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Error(err)
+			return ""
+		}
+		defer resp.Body.Close()
+
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("%s: got status %d: %s", path, resp.StatusCode, b)
+		}
+		return string(b)
+	}
+
+	countRuns := func() int {
+		b, err := ioutil.ReadFile(runs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.Count(string(b), "\n")
+	}
+
+	// concurrent requests share one collection
+	var wg sync.WaitGroup
+	for _, path := range []string{"/info", "/info/custom", "/render", "/metrics", "/info", "/render?format=plain"} {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			get(path)
+		}(path)
+	}
+	wg.Wait()
+	if n := countRuns(); n != 1 {
+		t.Errorf("got %d collections for concurrent requests, want 1", n)
+	}
+
+	// and the requests within the cache interval reuse it
+	if body := get("/render?format=plain"); !strings.Contains(body, "Runs: 1") {
+		t.Errorf("got %q, want the first collection", body)
+	}
+
+	time.Sleep(ttl)
+	if body := get("/render?format=plain"); !strings.Contains(body, "Runs: 2") {
+		t.Errorf("got %q, want a new collection once the cache expired", body)
+	}
+}
+
+func TestServeFlags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := writeConfig(t, dir, "[show]\nno_kernel = true\n")
+	socket := filepath.Join(dir, "archey.sock")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- execute(t, ioutil.Discard, "serve", "-c", config,
+			"--sysroot", "../archey/testdata/sysroot", "--no-shell", "--socket", socket)
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+
+	var resp *http.Response
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		select {
+		case err := <-done:
+			t.Fatalf("serve exited: %v", err)
+		default:
+		}
+
+		if resp, err = client.Get("http://archey/info"); err == nil {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal(err)
+		}
+	}
+
+	var info map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&info)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info["hostname"] != "fixture" {
+		t.Errorf("got hostname %v, want the one of the sysroot", info["hostname"])
+	}
+	for _, key := range []string{"kernel", "shell"} {
+		if _, ok := info[key]; ok {
+			t.Errorf("got %s %v, want it hidden", key, info[key])
+		}
+	}
+
+	resp, err = client.Get("http://archey/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# TYPE archey_packages gauge\n",
+		"archey_packages{manager=\"pacman\"} 3\n",
+		"archey_info{os=\"Arch Linux\"",
+	} {
+		if !strings.Contains(string(metrics), want) {
+			t.Errorf("missing %q in /metrics:\n%s", want, metrics)
+		}
+	}
+	if strings.Contains(string(metrics), "kernel=") {
+		t.Errorf("got the hidden kernel in /metrics:\n%s", metrics)
+	}
+
+	syscall.Kill(os.Getpid(), syscall.SIGTERM)
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
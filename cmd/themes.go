@@ -0,0 +1,79 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	archey "github.com/alexdreptu/archey-go/archey"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "Preview the available themes",
+	Long: `Preview every built-in theme and every theme file in
+~/.config/archey-go/themes with the info of this machine.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opt, err := newOptions()
+		if err != nil {
+			return err
+		}
+
+		if viper.GetBool("options.no_color") {
+			archey.NoColor()
+		}
+
+		files, err := archey.LoadThemes(archey.ThemesDir())
+		if err != nil {
+			return err
+		}
+
+		// collected once, every theme renders the same info
+		si, err := archey.Collect(opt)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range append(archey.Themes(), files...) {
+			o := *opt
+			o.Colors = archey.New().Colors
+			t.Apply(&o.Colors)
+
+			info, err := o.RenderInfo(si)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), t.Name)
+			fmt.Fprintln(cmd.OutOrStdout(), info)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(themesCmd)
+}
@@ -0,0 +1,57 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	archey "github.com/alexdreptu/archey-go/archey"
+)
+
+func TestThemesFlags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := writeConfig(t, dir, "[options]\nsep = \" ->\"\nmodules = [\"hostname\"]\n")
+
+	var out bytes.Buffer
+	if err := execute(t, &out, "themes", "-c", config,
+		"--sysroot", "../archey/testdata/sysroot", "--no-color"); err != nil {
+		t.Fatal(err)
+	}
+
+	got := out.String()
+	if strings.Contains(got, "\x1b[") {
+		t.Errorf("got colors with --no-color:\n%s", got)
+	}
+	if n, want := strings.Count(got, "Hostname -> fixture"), len(archey.Themes()); n < want {
+		t.Errorf("got %d previews with the config and the sysroot, want %d:\n%s", n, want, got)
+	}
+}
@@ -0,0 +1,127 @@
+// MIT License
+//
+// Copyright (c) 2016 Alexandru Dreptu
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	archey "github.com/alexdreptu/archey-go/archey"
+	"golang.org/x/term"
+)
+
+// default interval of --watch when it's given without one
+const defWatchInterval = 2 * time.Second
+
+// escape sequences of the watch mode
+const (
+	enterAltScreen = "\033[?1049h"
+	exitAltScreen  = "\033[?1049l"
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	cursorHome     = "\033[H"
+	clearLine      = "\033[K"
+	clearBelow     = "\033[J"
+)
+
+// keys quitting the watch mode
+const (
+	keyQuit  = 'q'
+	keyCtrlC = 3
+)
+
+var ErrWatchOutput = func(o string) error {
+	return fmt.Errorf("watch mode doesn't support the '%s' output format", o)
+}
+
+// watch redraws the info every interval on the alternate screen until
+// q or Ctrl-C is pressed, only collecting the dynamic fields again
+func watch(opt *archey.Options, interval time.Duration) error {
+	si, err := archey.Collect(opt)
+	if err != nil {
+		return err
+	}
+
+	quit := make(chan struct{}, 1)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	// without a terminal to read keys from only the signals quit
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer term.Restore(fd, state)
+
+		go func() {
+			b := make([]byte, 1)
+			for {
+				if _, err := os.Stdin.Read(b); err != nil {
+					return
+				}
+				if b[0] == keyQuit || b[0] == keyCtrlC {
+					quit <- struct{}{}
+					return
+				}
+			}
+		}()
+	}
+
+	fmt.Print(enterAltScreen + hideCursor)
+	defer fmt.Print(showCursor + exitAltScreen)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		info, err := opt.RenderInfo(si)
+		if err != nil {
+			return err
+		}
+
+		// the raw terminal doesn't return the carriage on new lines,
+		// and every line is cleared past its end instead of the whole
+		// screen so the redraw doesn't flicker
+		info = strings.Replace(info, "\n", clearLine+"\r\n", -1)
+		fmt.Print(cursorHome + info + clearBelow)
+
+		select {
+		case <-quit:
+			return nil
+		case <-signals:
+			return nil
+		case <-ticker.C:
+		}
+
+		if si, err = archey.Refresh(opt, si); err != nil {
+			return err
+		}
+	}
+}